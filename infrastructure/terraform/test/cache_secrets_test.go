@@ -71,6 +71,69 @@ func TestAWSCacheModuleWithHA(t *testing.T) {
 	assert.Contains(t, planOutput, "automatic_failover_enabled", "Plan should enable automatic failover")
 }
 
+func TestAWSCacheModuleWithGlobalDatastore(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "cache/aws")
+
+	vars := map[string]interface{}{
+		"name_prefix":       fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":       "test",
+		"instance_size":     "small",
+		"engine_version":    "7.0",
+		"high_availability": true,
+		"replica_count":     1,
+		"vpc_id":            "vpc-12345678",
+		"subnet_ids":        []string{"subnet-1", "subnet-2"},
+		"disaster_recovery": map[string]interface{}{
+			"enabled":          true,
+			"secondary_region": "us-west-2",
+			"mode":             "active-passive",
+			"rpo_seconds":      60,
+		},
+		"tags": config.Tags,
+	}
+
+	options := CreateCacheOptions(t, config, "aws", modulePath, vars)
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "aws_elasticache_global_replication_group", "Plan should create ElastiCache Global Datastore")
+	assert.Contains(t, planOutput, "primary_endpoint", "Plan should expose primary endpoint output")
+}
+
+func TestAWSCacheModuleWithCMEK(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "cache/aws")
+
+	vars := map[string]interface{}{
+		"name_prefix":    fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":    "test",
+		"instance_size":  "small",
+		"engine_version": "7.0",
+		"vpc_id":         "vpc-12345678",
+		"subnet_ids":     []string{"subnet-1", "subnet-2"},
+		"encryption": map[string]interface{}{
+			"mode":              "customer-managed",
+			"create_key":        true,
+			"key_rotation_days": 90,
+		},
+		"tags": config.Tags,
+	}
+
+	options := CreateCacheOptions(t, config, "aws", modulePath, vars)
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "aws_kms_key", "Plan should create a KMS key for CMEK")
+	assert.Contains(t, planOutput, "kms_key_id", "Plan should reference the KMS key on the replication group")
+}
+
 // =============================================================================
 // GCP Memorystore Tests
 // =============================================================================
@@ -137,6 +200,99 @@ func TestGCPCacheModuleWithHA(t *testing.T) {
 	assert.Contains(t, planOutput, "STANDARD_HA", "Plan should use HA tier")
 }
 
+func TestGCPCacheModuleWithCrossRegionReplica(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	if config.GCPProjectID == "" {
+		t.Skip("GCP_PROJECT_ID not set")
+	}
+
+	modulePath := GetModulePath(config.ModulesRootDir, "cache/gcp")
+
+	vars := map[string]interface{}{
+		"name_prefix":       fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":       "test",
+		"project_id":        config.GCPProjectID,
+		"region":            config.GCPRegion,
+		"instance_size":     "small",
+		"engine_version":    "7.0",
+		"high_availability": true,
+		"vpc_network_id":    fmt.Sprintf("projects/%s/global/networks/test-vpc", config.GCPProjectID),
+		"disaster_recovery": map[string]interface{}{
+			"enabled":          true,
+			"secondary_region": "us-east1",
+			"mode":             "active-passive",
+			"rpo_seconds":      60,
+		},
+		"labels": config.Tags,
+	}
+
+	options := CreateCacheOptions(t, config, "gcp", modulePath, vars)
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "google_redis_instance.secondary", "Plan should create Memorystore cross-region replica")
+	assert.Contains(t, planOutput, "secondary_endpoint", "Plan should expose secondary endpoint output")
+}
+
+// =============================================================================
+// Azure Cache for Redis Tests
+// =============================================================================
+
+func TestAzureCacheModulePlanOnly(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "cache/azure")
+
+	vars := map[string]interface{}{
+		"name_prefix":       fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":       "test",
+		"instance_size":     "micro",
+		"engine_version":    "7.0",
+		"high_availability": false,
+		"resource_group":    "terratest-rg",
+		"subnet_id":         "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/terratest-rg/providers/Microsoft.Network/virtualNetworks/test-vnet/subnets/test-subnet",
+		"tags":              config.Tags,
+	}
+
+	options := CreateCacheOptions(t, config, "azure", modulePath, vars)
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "azurerm_redis_cache", "Plan should create Azure Cache for Redis instance")
+}
+
+func TestAzureCacheModuleWithHA(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "cache/azure")
+
+	vars := map[string]interface{}{
+		"name_prefix":       fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":       "test",
+		"instance_size":     "small",
+		"engine_version":    "7.0",
+		"high_availability": true,
+		"replica_count":     2,
+		"resource_group":    "terratest-rg",
+		"subnet_id":         "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/terratest-rg/providers/Microsoft.Network/virtualNetworks/test-vnet/subnets/test-subnet",
+		"tags":              config.Tags,
+	}
+
+	options := CreateCacheOptions(t, config, "azure", modulePath, vars)
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "azurerm_redis_cache", "Plan should create Azure Cache for Redis instance")
+	assert.Contains(t, planOutput, "replicas_per_primary", "Plan should configure HA replicas")
+}
+
 // =============================================================================
 // Cloud-Agnostic Cache Tests
 // =============================================================================
@@ -217,6 +373,43 @@ func TestCloudAgnosticCacheModuleGCP(t *testing.T) {
 	assert.Contains(t, planOutput, "module.gcp", "Plan should use GCP module")
 }
 
+func TestCloudAgnosticCacheModuleAzure(t *testing.T) {
+	t.Parallel()
+	SkipIfMissingAzureCredentials(t)
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "cache")
+
+	vars := map[string]interface{}{
+		"cloud_provider":    "azure",
+		"name_prefix":       fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":       "test",
+		"instance_size":     "micro",
+		"engine_version":    "7.0",
+		"high_availability": false,
+		"tags":              config.Tags,
+		"azure_config": map[string]interface{}{
+			"resource_group":  "terratest-rg",
+			"subscription_id": config.AzureSubscriptionID,
+			"subnet_id":       "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/terratest-rg/providers/Microsoft.Network/virtualNetworks/test-vnet/subnets/test-subnet",
+		},
+	}
+
+	options := &terraform.Options{
+		TerraformDir: modulePath,
+		Vars:         vars,
+		EnvVars: map[string]string{
+			"ARM_SUBSCRIPTION_ID": config.AzureSubscriptionID,
+		},
+		NoColor: true,
+	}
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "module.azure", "Plan should use Azure module")
+}
+
 // =============================================================================
 // AWS Secrets Manager Tests
 // =============================================================================
@@ -280,6 +473,109 @@ func TestAWSSecretsModuleWithDatabaseCredentials(t *testing.T) {
 	assert.Contains(t, planOutput, "aws_secretsmanager_secret", "Plan should create database secret")
 }
 
+func TestAWSSecretsModuleWithRotation(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "secrets/aws")
+
+	vars := map[string]interface{}{
+		"name_prefix": fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment": "test",
+		"database_secrets": map[string]interface{}{
+			"control-plane-db": map[string]interface{}{
+				"host":     "db.example.com",
+				"port":     5432,
+				"database": "control_plane",
+				"username": "admin",
+				"password": "secretpass",
+				"engine":   "postgresql",
+				"rotation": map[string]interface{}{
+					"enabled":       true,
+					"schedule_days": 30,
+					"rotator":       "database-password",
+				},
+			},
+		},
+		"tags": config.Tags,
+	}
+
+	options := CreateSecretsOptions(t, config, "aws", modulePath, vars)
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "aws_secretsmanager_secret_rotation", "Plan should create rotation schedule")
+	assert.Contains(t, planOutput, "aws_lambda_function", "Plan should create rotator Lambda")
+	assert.Contains(t, planOutput, "aws_iam_role", "Plan should create rotation IAM role")
+}
+
+func TestAWSSecretsModuleWithCMEK(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "secrets/aws")
+
+	vars := map[string]interface{}{
+		"name_prefix": fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment": "test",
+		"secrets": map[string]interface{}{
+			"test-secret": map[string]interface{}{
+				"description": "Test secret",
+				"value": map[string]string{
+					"username": "testuser",
+					"password": "testpass",
+				},
+			},
+		},
+		"encryption": map[string]interface{}{
+			"mode":              "customer-managed",
+			"create_key":        true,
+			"key_rotation_days": 90,
+		},
+		"tags": config.Tags,
+	}
+
+	options := CreateSecretsOptions(t, config, "aws", modulePath, vars)
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "aws_kms_key", "Plan should create a KMS key for CMEK")
+	assert.Contains(t, planOutput, "kms_key_id", "Plan should reference the KMS key on the secret")
+}
+
+func TestAWSSecretsModuleWithCMEKRequiresKeyReference(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "secrets/aws")
+
+	vars := map[string]interface{}{
+		"name_prefix": fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment": "test",
+		"secrets": map[string]interface{}{
+			"test-secret": map[string]interface{}{
+				"description": "Test secret",
+				"value": map[string]string{
+					"username": "testuser",
+					"password": "testpass",
+				},
+			},
+		},
+		"encryption": map[string]interface{}{
+			"mode":       "customer-managed",
+			"create_key": false,
+		},
+		"tags": config.Tags,
+	}
+
+	options := CreateSecretsOptions(t, config, "aws", modulePath, vars)
+
+	_, err := terraform.InitAndPlanE(t, options)
+	assert.Error(t, err, "Plan should fail validation when customer-managed encryption has no kms_key_id and create_key is false")
+}
+
 // =============================================================================
 // GCP Secret Manager Tests
 // =============================================================================
@@ -353,6 +649,117 @@ func TestGCPSecretsModuleWithDatabaseCredentials(t *testing.T) {
 	assert.Contains(t, planOutput, "google_secret_manager_secret.database", "Plan should create database secret")
 }
 
+func TestGCPSecretsModuleWithRotation(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	if config.GCPProjectID == "" {
+		t.Skip("GCP_PROJECT_ID not set")
+	}
+
+	modulePath := GetModulePath(config.ModulesRootDir, "secrets/gcp")
+
+	vars := map[string]interface{}{
+		"name_prefix": fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment": "test",
+		"project_id":  config.GCPProjectID,
+		"database_secrets": map[string]interface{}{
+			"control-plane-db": map[string]interface{}{
+				"host":     "db.example.com",
+				"port":     5432,
+				"database": "control_plane",
+				"username": "admin",
+				"password": "secretpass",
+				"engine":   "postgresql",
+				"rotation": map[string]interface{}{
+					"enabled":       true,
+					"schedule_days": 30,
+					"rotator":       "database-password",
+				},
+			},
+		},
+		"labels": config.Tags,
+	}
+
+	options := CreateSecretsOptions(t, config, "gcp", modulePath, vars)
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "google_cloud_scheduler_job", "Plan should create rotation scheduler job")
+	assert.Contains(t, planOutput, "google_cloudfunctions_function", "Plan should create rotation Cloud Function")
+	assert.Contains(t, planOutput, "google_project_iam_member", "Plan should create rotation IAM binding")
+}
+
+// =============================================================================
+// Azure Key Vault Tests
+// =============================================================================
+
+func TestAzureSecretsModulePlanOnly(t *testing.T) {
+	t.Parallel()
+	SkipIfMissingAzureCredentials(t)
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "secrets/azure")
+
+	vars := map[string]interface{}{
+		"name_prefix":     fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":     "test",
+		"resource_group":  "terratest-rg",
+		"subscription_id": config.AzureSubscriptionID,
+		"secrets": map[string]interface{}{
+			"test-secret": map[string]interface{}{
+				"description": "Test secret",
+				"value": map[string]string{
+					"username": "testuser",
+					"password": "testpass",
+				},
+			},
+		},
+		"tags": config.Tags,
+	}
+
+	options := CreateSecretsOptions(t, config, "azure", modulePath, vars)
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "azurerm_key_vault_secret", "Plan should create Key Vault secret")
+}
+
+func TestAzureSecretsModuleWithDatabaseCredentials(t *testing.T) {
+	t.Parallel()
+	SkipIfMissingAzureCredentials(t)
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "secrets/azure")
+
+	vars := map[string]interface{}{
+		"name_prefix":     fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":     "test",
+		"resource_group":  "terratest-rg",
+		"subscription_id": config.AzureSubscriptionID,
+		"database_secrets": map[string]interface{}{
+			"control-plane-db": map[string]interface{}{
+				"host":     "db.example.com",
+				"port":     5432,
+				"database": "control_plane",
+				"username": "admin",
+				"password": "secretpass",
+				"engine":   "postgresql",
+			},
+		},
+		"tags": config.Tags,
+	}
+
+	options := CreateSecretsOptions(t, config, "azure", modulePath, vars)
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "azurerm_key_vault_secret.database", "Plan should create database secret")
+}
+
 // =============================================================================
 // Cloud-Agnostic Secrets Tests
 // =============================================================================
@@ -435,3 +842,44 @@ func TestCloudAgnosticSecretsModuleGCP(t *testing.T) {
 
 	assert.Contains(t, planOutput, "module.gcp", "Plan should use GCP module")
 }
+
+func TestCloudAgnosticSecretsModuleAzure(t *testing.T) {
+	t.Parallel()
+	SkipIfMissingAzureCredentials(t)
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "secrets")
+
+	vars := map[string]interface{}{
+		"cloud_provider": "azure",
+		"name_prefix":    fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":    "test",
+		"secrets": map[string]interface{}{
+			"test-secret": map[string]interface{}{
+				"description": "Test secret",
+				"value": map[string]string{
+					"key": "value",
+				},
+			},
+		},
+		"tags": config.Tags,
+		"azure_config": map[string]interface{}{
+			"resource_group":  "terratest-rg",
+			"subscription_id": config.AzureSubscriptionID,
+		},
+	}
+
+	options := &terraform.Options{
+		TerraformDir: modulePath,
+		Vars:         vars,
+		EnvVars: map[string]string{
+			"ARM_SUBSCRIPTION_ID": config.AzureSubscriptionID,
+		},
+		NoColor: true,
+	}
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "module.azure", "Plan should use Azure module")
+}
@@ -0,0 +1,45 @@
+// =============================================================================
+// Test-Stage Checkpointing
+// =============================================================================
+// TestCheckpoint is the small bit of state RunTerraformTest persists between
+// stages (module path, vars, outputs) so a developer can re-run only
+// validation against infrastructure a previous run already applied, via
+// SKIP_DEPLOY=true go test -run ...
+// =============================================================================
+
+package test
+
+import (
+	"testing"
+
+	test_structure "github.com/gruntwork-io/terratest/modules/test-structure"
+)
+
+const checkpointFile = "checkpoint.json"
+
+// TestCheckpoint captures what a resumed SKIP_DEPLOY=true run needs to know
+// about a prior stage's Terraform invocation.
+type TestCheckpoint struct {
+	ModulePath string                 `json:"module_path"`
+	Vars       map[string]interface{} `json:"vars,omitempty"`
+	Outputs    map[string]interface{} `json:"outputs,omitempty"`
+}
+
+// saveCheckpoint overwrites the checkpoint file in workingDir with checkpoint.
+func saveCheckpoint(t *testing.T, workingDir string, checkpoint TestCheckpoint) {
+	t.Helper()
+	test_structure.SaveTestData(t, test_structure.FormatTestDataPath(workingDir, checkpointFile), true, checkpoint)
+}
+
+// loadCheckpoint reads back the checkpoint previously saved in workingDir. It
+// returns a zero-value TestCheckpoint if none exists yet.
+func loadCheckpoint(t *testing.T, workingDir string) TestCheckpoint {
+	t.Helper()
+
+	var checkpoint TestCheckpoint
+	path := test_structure.FormatTestDataPath(workingDir, checkpointFile)
+	if test_structure.IsTestDataPresent(t, path) {
+		test_structure.LoadTestData(t, path, &checkpoint)
+	}
+	return checkpoint
+}
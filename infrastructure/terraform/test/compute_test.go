@@ -10,9 +10,13 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
+	http_helper "github.com/gruntwork-io/terratest/modules/http-helper"
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/ananta-platform/infrastructure-tests/gcpops"
 )
 
 // =============================================================================
@@ -45,7 +49,7 @@ func TestAWSECSModulePlanOnly(t *testing.T) {
 	// CRITICAL FIX: Add cleanup defer immediately after options creation
 	defer func() {
 		if os.Getenv("SKIP_CLEANUP") != "true" {
-			if err := terraform.DestroyE(t, options); err != nil {
+			if _, err := terraform.DestroyE(t, options); err != nil {
 				t.Logf("Error during cleanup: %v", err)
 			}
 		}
@@ -73,19 +77,20 @@ func TestGCPGKEModulePlanOnly(t *testing.T) {
 	modulePath := GetModulePath(config.ModulesRootDir, "compute/gcp")
 
 	vars := map[string]interface{}{
-		"name_prefix":           fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
-		"environment":           "test",
-		"project_id":            config.GCPProjectID,
-		"region":                config.GCPRegion,
-		"vpc_network_id":        fmt.Sprintf("projects/%s/global/networks/test-vpc", config.GCPProjectID),
-		"subnet_id":             fmt.Sprintf("projects/%s/regions/%s/subnetworks/test-subnet", config.GCPProjectID, config.GCPRegion),
-		"pods_range_name":       "pods",
-		"services_range_name":   "services",
-		"cluster_size":          "small",
-		"enable_private_nodes":  true,
+		"name_prefix":            fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":            "test",
+		"project_id":             config.GCPProjectID,
+		"region":                 config.GCPRegion,
+		"vpc_network_id":         fmt.Sprintf("projects/%s/global/networks/test-vpc", config.GCPProjectID),
+		"subnet_id":              fmt.Sprintf("projects/%s/regions/%s/subnetworks/test-subnet", config.GCPProjectID, config.GCPRegion),
+		"subnet_region":          config.GCPRegion,
+		"pods_range_name":        "pods",
+		"services_range_name":    "services",
+		"cluster_size":           "small",
+		"enable_private_nodes":   true,
 		"master_ipv4_cidr_block": "172.16.0.0/28",
-		"release_channel":       "REGULAR",
-		"labels":                config.Tags,
+		"release_channel":        "REGULAR",
+		"labels":                 config.Tags,
 	}
 
 	options := CreateComputeOptions(t, config, "gcp", modulePath, vars)
@@ -93,9 +98,16 @@ func TestGCPGKEModulePlanOnly(t *testing.T) {
 	// CRITICAL FIX: Add cleanup defer immediately after options creation
 	defer func() {
 		if os.Getenv("SKIP_CLEANUP") != "true" {
-			if err := terraform.DestroyE(t, options); err != nil {
+			if _, err := terraform.DestroyE(t, options); err != nil {
 				t.Logf("Error during cleanup: %v", err)
 			}
+
+			// Confirm the node pool/cluster delete operation actually
+			// reached DONE so a parallel test reusing this VPC/subnet
+			// doesn't race a still-deleting GKE cluster.
+			if opName, err := terraform.OutputE(t, options, "last_cluster_operation_name"); err == nil && opName != "" {
+				WaitForGCEOperationDone(t, config, gcpops.Zone, "", config.GCPRegion+"-a", opName)
+			}
 		}
 	}()
 
@@ -159,7 +171,7 @@ func TestGCPGKEModuleWithAdditionalNodePools(t *testing.T) {
 	// CRITICAL FIX: Add cleanup defer immediately after options creation
 	defer func() {
 		if os.Getenv("SKIP_CLEANUP") != "true" {
-			if err := terraform.DestroyE(t, options); err != nil {
+			if _, err := terraform.DestroyE(t, options); err != nil {
 				t.Logf("Error during cleanup: %v", err)
 			}
 		}
@@ -172,6 +184,92 @@ func TestGCPGKEModuleWithAdditionalNodePools(t *testing.T) {
 	assert.Contains(t, planOutput, "google_container_node_pool.additional", "Plan should create additional node pool")
 }
 
+// TestGCPGKEWithUserProvidedSubnet exercises a pre-existing VPC/subnetwork
+// whose region is decoupled from the cluster's control-plane region,
+// mirroring the CAPG regression where subnet lookup/deletion broke when
+// operators reused a subnet from another region.
+func TestGCPGKEWithUserProvidedSubnet(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	if config.GCPProjectID == "" {
+		t.Skip("GCP_PROJECT_ID not set")
+	}
+
+	modulePath := GetModulePath(config.ModulesRootDir, "compute/gcp")
+
+	subnetRegion := "us-east1"
+
+	vars := map[string]interface{}{
+		"name_prefix":            fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":            "test",
+		"project_id":             config.GCPProjectID,
+		"region":                 config.GCPRegion,
+		"vpc_network_id":         fmt.Sprintf("projects/%s/global/networks/shared-vpc", config.GCPProjectID),
+		"subnet_id":              fmt.Sprintf("projects/%s/regions/%s/subnetworks/shared-subnet", config.GCPProjectID, subnetRegion),
+		"subnet_region":          subnetRegion,
+		"pods_range_name":        "pods",
+		"services_range_name":    "services",
+		"cluster_size":           "small",
+		"enable_private_nodes":   true,
+		"master_ipv4_cidr_block": "172.16.0.0/28",
+		"release_channel":        "REGULAR",
+		"labels":                 config.Tags,
+	}
+
+	options := CreateComputeOptions(t, config, "gcp", modulePath, vars)
+
+	// CRITICAL FIX: Add cleanup defer immediately after options creation
+	defer func() {
+		if os.Getenv("SKIP_CLEANUP") != "true" {
+			if _, err := terraform.DestroyE(t, options); err != nil {
+				t.Logf("Error during cleanup: %v", err)
+			}
+		}
+	}()
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	// The subnet lookup should key off subnet_region, not region, so the
+	// plan still resolves even though region != subnet_region.
+	assert.Contains(t, planOutput, "google_container_cluster", "Plan should create GKE cluster")
+	assert.Contains(t, planOutput, "google_container_node_pool", "Plan should create node pool")
+	assert.Contains(t, planOutput, subnetRegion, "Plan should reference the subnet's own region, not the control-plane region")
+}
+
+func TestGCPGKEWithInvalidCrossRegionSecondaryRanges(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	if config.GCPProjectID == "" {
+		t.Skip("GCP_PROJECT_ID not set")
+	}
+
+	modulePath := GetModulePath(config.ModulesRootDir, "compute/gcp")
+
+	vars := map[string]interface{}{
+		"name_prefix":            fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":            "test",
+		"project_id":             config.GCPProjectID,
+		"region":                 config.GCPRegion,
+		"vpc_network_id":         fmt.Sprintf("projects/%s/global/networks/shared-vpc", config.GCPProjectID),
+		"subnet_id":              fmt.Sprintf("projects/%s/regions/us-east1/subnetworks/shared-subnet", config.GCPProjectID),
+		"subnet_region":          "us-east1",
+		"pods_range_name":        "does-not-exist-in-that-subnet",
+		"services_range_name":    "does-not-exist-either",
+		"cluster_size":           "small",
+		"enable_private_nodes":   true,
+		"master_ipv4_cidr_block": "172.16.0.0/28",
+		"labels":                 config.Tags,
+	}
+
+	options := CreateComputeOptions(t, config, "gcp", modulePath, vars)
+
+	_, err := terraform.InitAndPlanE(t, options)
+	assert.Error(t, err, "Plan should fail validation when the secondary ranges don't exist in the cross-region subnet")
+}
+
 // =============================================================================
 // Kubernetes Compute Tests
 // =============================================================================
@@ -230,7 +328,7 @@ func TestKubernetesComputeModulePlanOnly(t *testing.T) {
 	// CRITICAL FIX: Add cleanup defer immediately after options creation
 	defer func() {
 		if os.Getenv("SKIP_CLEANUP") != "true" {
-			if err := terraform.DestroyE(t, options); err != nil {
+			if _, err := terraform.DestroyE(t, options); err != nil {
 				t.Logf("Error during cleanup: %v", err)
 			}
 		}
@@ -244,6 +342,175 @@ func TestKubernetesComputeModulePlanOnly(t *testing.T) {
 	assert.Contains(t, planOutput, "kubernetes_service", "Plan should create service")
 }
 
+// TestKubernetesWorkloadWithLocalMySQLAccessory exercises the "local = true"
+// branch of a service's accessories block: the module should synthesize a
+// sidecar deployment/service for the database and wire its connection
+// details into the parent service via a generated secret.
+func TestKubernetesWorkloadWithLocalMySQLAccessory(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "compute/kubernetes")
+
+	vars := map[string]interface{}{
+		"name_prefix": fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment": "test",
+		"namespace":   config.K8sNamespace,
+		"services": map[string]interface{}{
+			"tenant-management-service": map[string]interface{}{
+				"image":     "arc-saas/tenant-management-service:latest",
+				"component": "api",
+				"replicas":  1,
+				"ports": []map[string]interface{}{
+					{
+						"name":     "http",
+						"port":     14000,
+						"protocol": "TCP",
+					},
+				},
+				"create_service": true,
+				"service_type":   "ClusterIP",
+				"accessories": map[string]interface{}{
+					"mysql": map[string]interface{}{
+						"engine":  "mysql",
+						"version": "8.0",
+						"size":    "small",
+						"local":   true,
+					},
+				},
+			},
+		},
+		"labels": config.Tags,
+	}
+
+	options := CreateComputeOptions(t, config, "kubernetes", modulePath, vars)
+
+	defer func() {
+		if os.Getenv("SKIP_CLEANUP") != "true" {
+			if _, err := terraform.DestroyE(t, options); err != nil {
+				t.Logf("Error during cleanup: %v", err)
+			}
+		}
+	}()
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "kubernetes_deployment", "Plan should create the parent service deployment")
+	assert.Contains(t, planOutput, "kubernetes_deployment.mysql", "Plan should create a sidecar deployment for the local MySQL accessory")
+	assert.Contains(t, planOutput, "kubernetes_service.mysql", "Plan should create a sidecar service for the local MySQL accessory")
+	assert.Contains(t, planOutput, "kubernetes_secret.mysql", "Plan should create a secret with the accessory's connection details")
+}
+
+// TestKubernetesWorkloadWithManagedRDSAccessory exercises the "local = false"
+// branch: instead of a sidecar, the module should emit a db_requirements
+// output describing the cloud database the workload needs, which the
+// database/aws module can consume to provision the real RDS instance.
+func TestKubernetesWorkloadWithManagedRDSAccessory(t *testing.T) {
+	t.Parallel()
+	SkipIfMissingKubernetesCredentials(t)
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "compute/kubernetes")
+
+	vars := map[string]interface{}{
+		"name_prefix": fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment": "test",
+		"namespace":   config.K8sNamespace,
+		"services": map[string]interface{}{
+			"tenant-management-service": map[string]interface{}{
+				"image":     "arc-saas/tenant-management-service:latest",
+				"component": "api",
+				"replicas":  1,
+				"ports": []map[string]interface{}{
+					{
+						"name":     "http",
+						"port":     14000,
+						"protocol": "TCP",
+					},
+				},
+				"create_service": true,
+				"service_type":   "ClusterIP",
+				"accessories": map[string]interface{}{
+					"mysql": map[string]interface{}{
+						"engine":  "mysql",
+						"version": "8.0",
+						"size":    "small",
+						"local":   false,
+					},
+				},
+			},
+		},
+		"labels": config.Tags,
+	}
+
+	options := CreateComputeOptions(t, config, "kubernetes", modulePath, vars)
+
+	defer func() {
+		if os.Getenv("SKIP_CLEANUP") != "true" {
+			if _, err := terraform.DestroyE(t, options); err != nil {
+				t.Logf("Error during cleanup: %v", err)
+			}
+		}
+	}()
+
+	terraform.InitAndApply(t, options)
+
+	dbRequirements := ValidateOutputMapHasKey(t, options, "db_requirements", "tenant-management-service.mysql")
+	assert.Contains(t, dbRequirements["tenant-management-service.mysql"], "mysql", "db_requirements entry should describe the engine the database/aws module must provision")
+}
+
+// TestKubernetesComputeModuleEndToEnd applies the Kubernetes compute module
+// and validates the workload in-cluster via client-go, rather than stopping
+// at a plan like TestKubernetesComputeModulePlanOnly.
+func TestKubernetesComputeModuleEndToEnd(t *testing.T) {
+	t.Parallel()
+	SkipIfMissingKubernetesCredentials(t)
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "compute/kubernetes")
+
+	vars := map[string]interface{}{
+		"name_prefix": fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment": "test",
+		"namespace":   config.K8sNamespace,
+		"services": map[string]interface{}{
+			"tenant-management-service": map[string]interface{}{
+				"image":     "arc-saas/tenant-management-service:latest",
+				"component": "api",
+				"replicas":  1,
+				"ports": []map[string]interface{}{
+					{
+						"name":     "http",
+						"port":     14000,
+						"protocol": "TCP",
+					},
+				},
+				"create_service": true,
+				"service_type":   "ClusterIP",
+			},
+		},
+		"labels": config.Tags,
+	}
+
+	options := CreateComputeOptions(t, config, "kubernetes", modulePath, vars)
+
+	defer func() {
+		if os.Getenv("SKIP_CLEANUP") != "true" {
+			if _, err := terraform.DestroyE(t, options); err != nil {
+				t.Logf("Error during cleanup: %v", err)
+			}
+		}
+	}()
+
+	terraform.InitAndApply(t, options)
+
+	k8sOptions := CreateK8sOptions(t, config, options)
+	ValidateNamespaceExists(t, k8sOptions)
+	ValidateDeploymentReady(t, k8sOptions, "tenant-management-service", 2*time.Minute)
+	ValidateServiceEndpoint(t, k8sOptions, "tenant-management-service", 2*time.Minute)
+}
+
 // =============================================================================
 // Integration Tests - Full Stack
 // =============================================================================
@@ -263,6 +530,7 @@ func TestGCPFullStackPlanOnly(t *testing.T) {
 		"cache/gcp",
 		"secrets/gcp",
 		"compute/gcp",
+		"loadbalancer/gcp",
 	}
 
 	for _, module := range modules {
@@ -290,9 +558,16 @@ func TestGCPFullStackPlanOnly(t *testing.T) {
 			// CRITICAL FIX: Add cleanup defer immediately after options creation
 			defer func() {
 				if os.Getenv("SKIP_CLEANUP") != "true" {
-					if err := terraform.DestroyE(t, options); err != nil {
+					if _, err := terraform.DestroyE(t, options); err != nil {
 						t.Logf("Error during cleanup for %s: %v", module, err)
 					}
+
+					// Confirm any GCE delete operation this module's
+					// destroy kicked off actually reached DONE before the
+					// next module in the stack reuses its network.
+					if opName, err := terraform.OutputE(t, options, "last_operation_name"); err == nil && opName != "" {
+						WaitForGCEOperationDone(t, config, gcpops.Region, config.GCPRegion, "", opName)
+					}
 				}
 			}()
 
@@ -316,6 +591,7 @@ func TestAWSFullStackPlanOnly(t *testing.T) {
 		"cache/aws",
 		"secrets/aws",
 		"ecs",
+		"loadbalancer/aws",
 	}
 
 	for _, module := range modules {
@@ -339,7 +615,7 @@ func TestAWSFullStackPlanOnly(t *testing.T) {
 			// CRITICAL FIX: Add cleanup defer immediately after options creation
 			defer func() {
 				if os.Getenv("SKIP_CLEANUP") != "true" {
-					if err := terraform.DestroyE(t, options); err != nil {
+					if _, err := terraform.DestroyE(t, options); err != nil {
 						t.Logf("Error during cleanup for %s: %v", module, err)
 					}
 				}
@@ -351,3 +627,64 @@ func TestAWSFullStackPlanOnly(t *testing.T) {
 		})
 	}
 }
+
+// TestAWSFullStackEndToEnd applies the AWS stack (network and loadbalancer)
+// for real and confirms the ALB actually routes traffic to the ECS service,
+// rather than just validating that every module's config is well-formed the
+// way TestAWSFullStackPlanOnly does.
+func TestAWSFullStackEndToEnd(t *testing.T) {
+	t.Parallel()
+	SkipIfMissingAWSCredentials(t)
+
+	config := NewTestConfig(t)
+
+	networkOptions := CreateAWSNetworkOptions(t, config, GetModulePath(config.ModulesRootDir, "network/aws"), nil)
+	defer terraform.Destroy(t, networkOptions)
+	terraform.InitAndApply(t, networkOptions)
+	vpcID := ValidateOutputNotEmpty(t, networkOptions, "vpc_id")
+
+	lbVars := map[string]interface{}{
+		"vpc_id":           vpcID,
+		"subnet_ids":       terraform.OutputList(t, networkOptions, "public_subnet_ids"),
+		"ecs_service_name": fmt.Sprintf("%s-%s-service", TestPrefix, config.UniqueID),
+		"ecs_cluster_arn":  fmt.Sprintf("arn:aws:ecs:%s:123456789012:cluster/test-cluster", config.AWSRegion),
+		"container_port":   8080,
+		"tags":             config.Tags,
+	}
+	lbOptions := CreateLoadBalancerOptions(t, config, "aws", GetModulePath(config.ModulesRootDir, "loadbalancer/aws"), lbVars)
+	defer terraform.Destroy(t, lbOptions)
+	terraform.InitAndApply(t, lbOptions)
+
+	lbAddress := ValidateOutputNotEmpty(t, lbOptions, "lb_address")
+	http_helper.HttpGetWithRetry(t, fmt.Sprintf("http://%s/healthz", lbAddress), nil, 200, "", 30, 10*time.Second)
+}
+
+// TestGCPFullStackEndToEnd is the GCP counterpart of
+// TestAWSFullStackEndToEnd: apply the network and loadbalancer modules for
+// real and confirm the forwarding rule actually serves HTTP 200.
+func TestGCPFullStackEndToEnd(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	if config.GCPProjectID == "" {
+		t.Skip("GCP_PROJECT_ID not set")
+	}
+
+	networkOptions := CreateGCPNetworkOptions(t, config, GetModulePath(config.ModulesRootDir, "network/gcp"), nil)
+	defer terraform.Destroy(t, networkOptions)
+	terraform.InitAndApply(t, networkOptions)
+
+	lbVars := map[string]interface{}{
+		"project_id":     config.GCPProjectID,
+		"region":         config.GCPRegion,
+		"instance_group": fmt.Sprintf("projects/%s/zones/%s-a/instanceGroups/gke-node-pool", config.GCPProjectID, config.GCPRegion),
+		"target_port":    8080,
+		"labels":         config.Tags,
+	}
+	lbOptions := CreateLoadBalancerOptions(t, config, "gcp", GetModulePath(config.ModulesRootDir, "loadbalancer/gcp"), lbVars)
+	defer terraform.Destroy(t, lbOptions)
+	terraform.InitAndApply(t, lbOptions)
+
+	lbAddress := ValidateOutputNotEmpty(t, lbOptions, "lb_address")
+	http_helper.HttpGetWithRetry(t, fmt.Sprintf("http://%s/healthz", lbAddress), nil, 200, "", 30, 10*time.Second)
+}
@@ -7,11 +7,15 @@
 package test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ananta-platform/infrastructure-tests/waiters"
 )
 
 // =============================================================================
@@ -56,6 +60,13 @@ func TestAWSDatabaseModuleBasic(t *testing.T) {
 
 	terraform.InitAndApply(t, options)
 
+	// Poll the RDS API until it reports the instance "available" before
+	// asserting on outputs. This checks the status the API reports; it does
+	// not open a connection to the database.
+	dbInstanceID := terraform.Output(t, options, "db_instance_identifier")
+	_, err := waiters.WaitForDBReady(context.Background(), t, "aws", config.AWSRegion, dbInstanceID, waiters.Options{})
+	require.NoError(t, err, "RDS instance should become available")
+
 	// Validate outputs
 	endpoint := ValidateOutputNotEmpty(t, options, "endpoint")
 	assert.NotEmpty(t, endpoint, "Database endpoint should not be empty")
@@ -96,6 +107,42 @@ func TestAWSDatabaseModulePlanOnly(t *testing.T) {
 	assert.Contains(t, planOutput, "aws_security_group", "Plan should create security group")
 }
 
+func TestAWSDatabaseModuleWithCrossRegionReplica(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "database/aws")
+
+	vars := map[string]interface{}{
+		"name_prefix":          fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":          "test",
+		"instance_size":        "micro",
+		"engine_version":       "15",
+		"database_name":        "testdb",
+		"master_username":      "testuser",
+		"vpc_id":               "vpc-12345678",
+		"db_subnet_group_name": "test-subnet-group",
+		"storage_gb":           20,
+		"disaster_recovery": map[string]interface{}{
+			"enabled":          true,
+			"secondary_region": "us-west-2",
+			"mode":             "active-passive",
+			"rpo_seconds":      300,
+		},
+		"tags": config.Tags,
+	}
+
+	options := CreateDatabaseOptions(t, config, "aws", modulePath, vars)
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	// Validate plan contains expected resources
+	assert.Contains(t, planOutput, "aws_db_instance", "Plan should create primary RDS instance")
+	assert.Contains(t, planOutput, "aws_db_instance.replica", "Plan should create cross-region read replica")
+	assert.Contains(t, planOutput, "failover_dns", "Plan should expose failover DNS output")
+}
+
 // =============================================================================
 // GCP Cloud SQL Database Tests
 // =============================================================================
@@ -144,6 +191,13 @@ func TestGCPDatabaseModuleBasic(t *testing.T) {
 
 	terraform.InitAndApply(t, options)
 
+	// Poll the Cloud SQL Admin API until it reports the instance RUNNABLE
+	// before reading outputs. This checks the state the API reports; it does
+	// not open a connection to the database.
+	instanceName := terraform.Output(t, options, "instance_name")
+	_, err := waiters.WaitForDBReady(context.Background(), t, "gcp", config.GCPRegion, fmt.Sprintf("%s/%s", config.GCPProjectID, instanceName), waiters.Options{})
+	require.NoError(t, err, "Cloud SQL instance should become available")
+
 	// Validate outputs
 	connectionName := ValidateOutputNotEmpty(t, options, "connection_name")
 	assert.Contains(t, connectionName, config.GCPProjectID, "Connection name should contain project ID")
@@ -187,6 +241,120 @@ func TestGCPDatabaseModulePlanOnly(t *testing.T) {
 	assert.Contains(t, planOutput, "google_sql_user", "Plan should create user")
 }
 
+func TestGCPDatabaseModuleWithCrossRegionReplica(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	if config.GCPProjectID == "" {
+		t.Skip("GCP_PROJECT_ID not set")
+	}
+
+	modulePath := GetModulePath(config.ModulesRootDir, "database/gcp")
+
+	vars := map[string]interface{}{
+		"name_prefix":     fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":     "test",
+		"project_id":      config.GCPProjectID,
+		"region":          config.GCPRegion,
+		"instance_size":   "micro",
+		"engine_version":  "15",
+		"database_name":   "testdb",
+		"master_username": "testuser",
+		"vpc_network_id":  "projects/test/global/networks/test-vpc",
+		"storage_gb":      20,
+		"disaster_recovery": map[string]interface{}{
+			"enabled":          true,
+			"secondary_region": "us-east1",
+			"mode":             "active-passive",
+			"rpo_seconds":      300,
+		},
+		"labels": config.Tags,
+	}
+
+	options := CreateDatabaseOptions(t, config, "gcp", modulePath, vars)
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "google_sql_database_instance.replica", "Plan should create Cloud SQL cross-region replica")
+	assert.Contains(t, planOutput, "replica_configuration", "Plan should set replica_configuration")
+	assert.Contains(t, planOutput, "failover_dns", "Plan should expose failover DNS output")
+}
+
+func TestGCPDatabaseModuleWithCMEK(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	if config.GCPProjectID == "" {
+		t.Skip("GCP_PROJECT_ID not set")
+	}
+
+	modulePath := GetModulePath(config.ModulesRootDir, "database/gcp")
+
+	vars := map[string]interface{}{
+		"name_prefix":     fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":     "test",
+		"project_id":      config.GCPProjectID,
+		"region":          config.GCPRegion,
+		"instance_size":   "micro",
+		"engine_version":  "15",
+		"database_name":   "testdb",
+		"master_username": "testuser",
+		"vpc_network_id":  "projects/test/global/networks/test-vpc",
+		"storage_gb":      20,
+		"encryption": map[string]interface{}{
+			"mode":              "customer-managed",
+			"create_key":        true,
+			"key_rotation_days": 90,
+		},
+		"labels": config.Tags,
+	}
+
+	options := CreateDatabaseOptions(t, config, "gcp", modulePath, vars)
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "google_kms_crypto_key", "Plan should create a KMS key for CMEK")
+	assert.Contains(t, planOutput, "encryption_key_name", "Plan should reference the KMS key on the Cloud SQL instance")
+}
+
+// =============================================================================
+// Azure Database for PostgreSQL Flexible Server Tests
+// =============================================================================
+
+func TestAzureDatabaseModulePlanOnly(t *testing.T) {
+	t.Parallel()
+	SkipIfMissingAzureCredentials(t)
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "database/azure")
+
+	vars := map[string]interface{}{
+		"name_prefix":         fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":         "test",
+		"resource_group":      "terratest-rg",
+		"region":              config.AzureRegion,
+		"instance_size":       "micro",
+		"engine_version":      "15",
+		"database_name":       "testdb",
+		"master_username":     "testuser",
+		"vnet_id":             "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/terratest-rg/providers/Microsoft.Network/virtualNetworks/test-vnet",
+		"subnet_id":           "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/terratest-rg/providers/Microsoft.Network/virtualNetworks/test-vnet/subnets/test-subnet",
+		"private_dns_zone_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/terratest-rg/providers/Microsoft.Network/privateDnsZones/test.postgres.database.azure.com",
+		"storage_gb":          20,
+		"tags":                config.Tags,
+	}
+
+	options := CreateDatabaseOptions(t, config, "azure", modulePath, vars)
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	// Validate plan contains expected resources
+	assert.Contains(t, planOutput, "azurerm_postgresql_flexible_server", "Plan should create Azure Database for PostgreSQL Flexible Server")
+}
+
 // =============================================================================
 // Cloud-Agnostic Database Tests
 // =============================================================================
@@ -270,3 +438,124 @@ func TestCloudAgnosticDatabaseModuleGCP(t *testing.T) {
 
 	assert.Contains(t, planOutput, "module.gcp", "Plan should use GCP module")
 }
+
+func TestCloudAgnosticDatabaseModuleAzure(t *testing.T) {
+	t.Parallel()
+	SkipIfMissingAzureCredentials(t)
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "database")
+
+	vars := map[string]interface{}{
+		"cloud_provider":  "azure",
+		"name_prefix":     fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":     "test",
+		"instance_size":   "micro",
+		"engine_version":  "15",
+		"database_name":   "testdb",
+		"master_username": "testuser",
+		"tags":            config.Tags,
+		"azure_config": map[string]interface{}{
+			"resource_group":      "terratest-rg",
+			"subscription_id":     config.AzureSubscriptionID,
+			"region":              config.AzureRegion,
+			"vnet_id":             "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/terratest-rg/providers/Microsoft.Network/virtualNetworks/test-vnet",
+			"subnet_id":           "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/terratest-rg/providers/Microsoft.Network/virtualNetworks/test-vnet/subnets/test-subnet",
+			"private_dns_zone_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/terratest-rg/providers/Microsoft.Network/privateDnsZones/test.postgres.database.azure.com",
+			"storage_gb":          20,
+		},
+	}
+
+	options := &terraform.Options{
+		TerraformDir: modulePath,
+		Vars:         vars,
+		EnvVars: map[string]string{
+			"ARM_SUBSCRIPTION_ID": config.AzureSubscriptionID,
+		},
+		NoColor: true,
+	}
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "module.azure", "Plan should use Azure module")
+}
+
+// =============================================================================
+// Multi-Engine Database Tests
+// =============================================================================
+
+// databaseEngineExpectation captures the per-engine, per-provider defaults
+// that TestDatabaseModuleEngineMatrix asserts against: the default port, the
+// resource type the plan should create, and the database_version/engine
+// string the provider expects.
+type databaseEngineExpectation struct {
+	provider        string
+	engine          string
+	expectedPort    string
+	expectedVersion string
+	resourceType    string
+}
+
+func TestDatabaseModuleEngineMatrix(t *testing.T) {
+	cases := []databaseEngineExpectation{
+		{provider: "aws", engine: "postgres", expectedPort: "5432", expectedVersion: "15", resourceType: "aws_db_instance"},
+		{provider: "aws", engine: "mysql", expectedPort: "3306", expectedVersion: "8.0", resourceType: "aws_db_instance"},
+		{provider: "aws", engine: "sqlserver", expectedPort: "1433", expectedVersion: "15.00", resourceType: "aws_db_instance"},
+		{provider: "aws", engine: "aurora-postgres", expectedPort: "5432", expectedVersion: "15.4", resourceType: "aws_rds_cluster"},
+		{provider: "aws", engine: "aurora-mysql", expectedPort: "3306", expectedVersion: "8.0", resourceType: "aws_rds_cluster"},
+		{provider: "gcp", engine: "postgres", expectedPort: "5432", expectedVersion: "POSTGRES_15", resourceType: "google_sql_database_instance"},
+		{provider: "gcp", engine: "mysql", expectedPort: "3306", expectedVersion: "MYSQL_8_0", resourceType: "google_sql_database_instance"},
+		{provider: "gcp", engine: "sqlserver", expectedPort: "1433", expectedVersion: "SQLSERVER_2019_STANDARD", resourceType: "google_sql_database_instance"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(fmt.Sprintf("%s/%s", tc.provider, tc.engine), func(t *testing.T) {
+			t.Parallel()
+
+			config := NewTestConfig(t)
+			if tc.provider == "gcp" && config.GCPProjectID == "" {
+				t.Skip("GCP_PROJECT_ID not set")
+			}
+
+			modulePath := GetModulePath(config.ModulesRootDir, fmt.Sprintf("database/%s", tc.provider))
+
+			vars := map[string]interface{}{
+				"name_prefix":     fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+				"environment":     "test",
+				"instance_size":   "micro",
+				"engine":          tc.engine,
+				"database_name":   "testdb",
+				"master_username": "testuser",
+				"tags":            config.Tags,
+			}
+			if tc.provider == "aws" {
+				vars["vpc_id"] = "vpc-12345678"
+				vars["db_subnet_group_name"] = "test-subnet-group"
+				vars["storage_gb"] = 20
+			} else {
+				vars["project_id"] = config.GCPProjectID
+				vars["region"] = config.GCPRegion
+				vars["vpc_network_id"] = "projects/test/global/networks/test-vpc"
+				vars["storage_gb"] = 20
+				vars["labels"] = config.Tags
+			}
+
+			options := CreateDatabaseOptions(t, config, tc.provider, modulePath, vars)
+
+			terraform.Init(t, options)
+			planOutput := terraform.Plan(t, options)
+
+			assert.Contains(t, planOutput, tc.resourceType, "Plan should create a %s for engine %s", tc.resourceType, tc.engine)
+			assert.Contains(t, planOutput, tc.expectedVersion, "Plan should request engine version %s", tc.expectedVersion)
+
+			// port and jdbc_url are derived from the instance endpoint, which
+			// isn't known until apply, so assert against the plan text (as
+			// the rest of the plan-only tests do) instead of reading
+			// outputs from a state this plan-only run never creates.
+			assert.Contains(t, planOutput, tc.expectedPort, "Plan should configure port %s for engine %s", tc.expectedPort, tc.engine)
+			assert.Contains(t, planOutput, "jdbc_url", "Plan should expose a jdbc_url output")
+		})
+	}
+}
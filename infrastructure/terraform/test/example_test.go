@@ -61,10 +61,10 @@ func TestTerraformModuleValidation(t *testing.T) {
 			}
 
 			// Validate the module
-			err := terraform.InitE(t, terraformOptions)
+			_, err := terraform.InitE(t, terraformOptions)
 			assert.NoError(t, err, "Terraform init should succeed")
 
-			err = terraform.ValidateE(t, terraformOptions)
+			_, err = terraform.ValidateE(t, terraformOptions)
 			assert.NoError(t, err, "Terraform validate should succeed")
 		})
 	}
@@ -0,0 +1,37 @@
+package functional
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+// resolveAWSCredentials fetches and decodes the secret the secrets module
+// published for this resource. The secret ARN is read from the
+// secretsModuleOpts module's "secret_arn" output.
+func resolveAWSCredentials(t *testing.T, secretsModuleOpts *terraform.Options) *credentials {
+	secretARN := terraform.Output(t, secretsModuleOpts, "secret_arn")
+	region := secretsModuleOpts.EnvVars["AWS_DEFAULT_REGION"]
+
+	cfg, err := awsconfig.LoadDefaultConfig(context.Background(), awsconfig.WithRegion(region))
+	require.NoError(t, err, "should be able to load AWS config")
+
+	client := secretsmanager.NewFromConfig(cfg)
+	out, err := client.GetSecretValue(context.Background(), &secretsmanager.GetSecretValueInput{
+		SecretId: &secretARN,
+	})
+	require.NoError(t, err, "should be able to read the secret from Secrets Manager")
+	require.NotNil(t, out.SecretString, "secret %s should have a string value", secretARN)
+
+	var creds credentials
+	require.NoError(t, json.Unmarshal([]byte(*out.SecretString), &creds), fmt.Sprintf("secret %s should decode as credentials JSON", secretARN))
+
+	return &creds
+}
@@ -0,0 +1,123 @@
+// =============================================================================
+// Cache Functional Smoke Tests
+// =============================================================================
+// Applies the secrets + cache modules for real and exercises the resulting
+// Redis endpoint end to end. Opt-in: set RUN_FUNCTIONAL_TESTS=1. This closes
+// the gap where a module plans and applies cleanly but produces an endpoint
+// nobody can actually reach.
+// =============================================================================
+
+package functional
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testpkg "github.com/ananta-platform/infrastructure-tests"
+)
+
+func skipUnlessFunctionalTestsEnabled(t *testing.T) {
+	t.Helper()
+	if os.Getenv(FunctionalTestEnvVar) != "1" {
+		t.Skipf("set %s=1 to run functional smoke tests against real infrastructure", FunctionalTestEnvVar)
+	}
+}
+
+func TestAWSCacheSmoke(t *testing.T) {
+	skipUnlessFunctionalTestsEnabled(t)
+	t.Parallel()
+	testpkg.SkipIfMissingAWSCredentials(t)
+
+	config := testpkg.NewTestConfig(t)
+
+	secretsPath := testpkg.GetModulePath(config.ModulesRootDir, "secrets/aws")
+	secretsOptions := testpkg.CreateSecretsOptions(t, config, "aws", secretsPath, map[string]interface{}{
+		"database_secrets": map[string]interface{}{
+			"cache-auth": map[string]interface{}{
+				"host":     "", // not read: ConnectRedisFromSecret takes the address from the cache module's outputs
+				"port":     "6379",
+				"database": "",
+				"username": "",
+				"password": "",
+				"engine":   "redis",
+			},
+		},
+	})
+	defer terraform.Destroy(t, secretsOptions)
+	terraform.InitAndApply(t, secretsOptions)
+
+	cachePath := testpkg.GetModulePath(config.ModulesRootDir, "cache/aws")
+	cacheOptions := testpkg.CreateCacheOptions(t, config, "aws", cachePath, map[string]interface{}{
+		"vpc_id":                "vpc-12345678",
+		"subnet_ids":            []string{"subnet-1", "subnet-2"},
+		"auth_token_secret_arn": terraform.Output(t, secretsOptions, "secret_arn"),
+	})
+	defer terraform.Destroy(t, cacheOptions)
+	terraform.InitAndApply(t, cacheOptions)
+
+	client := ConnectRedisFromSecret(t, "aws", secretsOptions, cacheOptions)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+
+	require.NoError(t, client.Set(ctx, "terratest-smoke-key", "terratest-smoke-value", time.Minute).Err(), "SET should succeed")
+
+	value, err := client.Get(ctx, "terratest-smoke-key").Result()
+	require.NoError(t, err, "GET should succeed")
+	assert.Equal(t, "terratest-smoke-value", value, "GET should return the value just written")
+
+	badClient := redis.NewClient(&redis.Options{
+		Addr:     client.Options().Addr,
+		Password: "definitely-the-wrong-password",
+	})
+	defer badClient.Close()
+	assert.Error(t, badClient.Ping(ctx).Err(), "authenticating with the wrong password should be rejected")
+}
+
+func TestGCPCacheSmoke(t *testing.T) {
+	skipUnlessFunctionalTestsEnabled(t)
+	t.Parallel()
+	testpkg.SkipIfMissingGCPCredentials(t)
+
+	config := testpkg.NewTestConfig(t)
+	if config.GCPProjectID == "" {
+		t.Skip("GCP_PROJECT_ID not set")
+	}
+
+	secretsPath := testpkg.GetModulePath(config.ModulesRootDir, "secrets/gcp")
+	secretsOptions := testpkg.CreateSecretsOptions(t, config, "gcp", secretsPath, map[string]interface{}{
+		"project_id": config.GCPProjectID,
+	})
+	defer terraform.Destroy(t, secretsOptions)
+	terraform.InitAndApply(t, secretsOptions)
+
+	cachePath := testpkg.GetModulePath(config.ModulesRootDir, "cache/gcp")
+	cacheOptions := testpkg.CreateCacheOptions(t, config, "gcp", cachePath, map[string]interface{}{
+		"project_id":     config.GCPProjectID,
+		"region":         config.GCPRegion,
+		"vpc_network_id": fmt.Sprintf("projects/%s/global/networks/test-vpc", config.GCPProjectID),
+	})
+	defer terraform.Destroy(t, cacheOptions)
+	terraform.InitAndApply(t, cacheOptions)
+
+	client := ConnectRedisFromSecret(t, "gcp", secretsOptions, cacheOptions)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+
+	require.NoError(t, client.Set(ctx, "terratest-smoke-key", "terratest-smoke-value", time.Minute).Err(), "SET should succeed")
+
+	value, err := client.Get(ctx, "terratest-smoke-key").Result()
+	require.NoError(t, err, "GET should succeed")
+	assert.Equal(t, "terratest-smoke-value", value, "GET should return the value just written")
+}
@@ -0,0 +1,37 @@
+package functional
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"google.golang.org/api/secretmanager/v1"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+// resolveGCPCredentials fetches and decodes the secret the secrets module
+// published for this resource. The secret's resource name is read from the
+// secretsModuleOpts module's "secret_id" output, e.g.
+// "projects/my-project/secrets/my-secret".
+func resolveGCPCredentials(t *testing.T, secretsModuleOpts *terraform.Options) *credentials {
+	secretID := terraform.Output(t, secretsModuleOpts, "secret_id")
+	versionName := fmt.Sprintf("%s/versions/latest", secretID)
+
+	svc, err := secretmanager.NewService(context.Background())
+	require.NoError(t, err, "should be able to create a Secret Manager client")
+
+	resp, err := svc.Projects.Secrets.Versions.Access(versionName).Do()
+	require.NoError(t, err, "should be able to access the latest version of secret %s", secretID)
+
+	data, err := base64.StdEncoding.DecodeString(resp.Payload.Data)
+	require.NoError(t, err, "secret %s payload should be valid base64", secretID)
+
+	var creds credentials
+	require.NoError(t, json.Unmarshal(data, &creds), fmt.Sprintf("secret %s should decode as credentials JSON", secretID))
+
+	return &creds
+}
@@ -0,0 +1,101 @@
+// =============================================================================
+// Functional Smoke-Test Helpers
+// =============================================================================
+// Connects to the Redis/database endpoints a terraform apply actually
+// produced, using the credentials the secrets module wrote for it, so the
+// opt-in functional suite (cache_smoke_test.go, database_smoke_test.go) can
+// assert the provisioned resources are actually reachable rather than just
+// inspecting Terraform outputs.
+// =============================================================================
+
+package functional
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+// FunctionalTestEnvVar gates the whole functional suite: these tests apply
+// real infrastructure and connect to it over the network, so they only run
+// when explicitly requested.
+const FunctionalTestEnvVar = "RUN_FUNCTIONAL_TESTS"
+
+// connectTimeout bounds how long a smoke test waits to establish a
+// connection to a freshly-applied, possibly not-yet-reachable endpoint.
+const connectTimeout = 30 * time.Second
+
+// credentials mirrors the JSON shape the secrets module writes for a
+// database/cache secret: host, port, username, password, database.
+type credentials struct {
+	Host     string `json:"host"`
+	Port     string `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Database string `json:"database"`
+}
+
+// ConnectRedisFromSecret resolves the cache secret produced by
+// secretsModuleOpts, dials the endpoint exposed by cacheModuleOpts, and
+// returns a connected client. Callers are responsible for closing it.
+func ConnectRedisFromSecret(t *testing.T, provider string, secretsModuleOpts, cacheModuleOpts *terraform.Options) *redis.Client {
+	creds := resolveCredentials(t, provider, secretsModuleOpts)
+
+	address := terraform.Output(t, cacheModuleOpts, "primary_endpoint_address")
+	port := terraform.Output(t, cacheModuleOpts, "port")
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%s", address, port),
+		Password: creds.Password,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+	require.NoError(t, client.Ping(ctx).Err(), "should be able to PING the provisioned Redis endpoint")
+
+	return client
+}
+
+// ConnectSQLFromSecret resolves the database secret produced by
+// secretsModuleOpts, opens a connection to the endpoint exposed by
+// databaseModuleOpts, and returns a ready-to-use *sql.DB. Callers are
+// responsible for closing it.
+func ConnectSQLFromSecret(t *testing.T, provider string, secretsModuleOpts, databaseModuleOpts *terraform.Options) *sql.DB {
+	creds := resolveCredentials(t, provider, secretsModuleOpts)
+
+	host := terraform.Output(t, databaseModuleOpts, "endpoint")
+	port := terraform.Output(t, databaseModuleOpts, "port")
+	dbName := terraform.Output(t, databaseModuleOpts, "database_name")
+
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=require",
+		host, port, creds.Username, creds.Password, dbName)
+
+	db, err := sql.Open("postgres", dsn)
+	require.NoError(t, err, "should be able to open a connection to the provisioned database")
+
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+	require.NoError(t, db.PingContext(ctx), "should be able to reach the provisioned database endpoint")
+
+	return db
+}
+
+func resolveCredentials(t *testing.T, provider string, secretsModuleOpts *terraform.Options) *credentials {
+	switch provider {
+	case "aws":
+		return resolveAWSCredentials(t, secretsModuleOpts)
+	case "gcp":
+		return resolveGCPCredentials(t, secretsModuleOpts)
+	default:
+		require.FailNow(t, fmt.Sprintf("functional: unsupported provider %q", provider))
+		return nil
+	}
+}
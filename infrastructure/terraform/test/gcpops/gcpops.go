@@ -0,0 +1,213 @@
+// =============================================================================
+// GCE Long-Running Operation Waiter
+// =============================================================================
+// ComputeOperationWaiter blocks until a global/regional/zonal Compute Engine
+// operation reaches DONE, so terratest cleanup (and tests that assert on
+// GKE/network resources right after InitAndApply or DestroyE) don't race the
+// underlying GCE API. Modeled on the common GCE waiter pattern used by the
+// Terraform and Cluster API GCP providers.
+// =============================================================================
+
+package gcpops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// Type identifies which Operations endpoint a ComputeOperationWaiter should
+// poll: GlobalOperations, RegionOperations, or ZoneOperations.
+type Type int
+
+const (
+	Global Type = iota
+	Region
+	Zone
+)
+
+func (t Type) String() string {
+	switch t {
+	case Global:
+		return "global"
+	case Region:
+		return "region"
+	case Zone:
+		return "zone"
+	default:
+		return "unknown"
+	}
+}
+
+// DefaultTimeout and DefaultInterval are used when WaitForState is called
+// with a zero timeout or the waiter's Interval is left unset.
+const (
+	DefaultTimeout  = 10 * time.Minute
+	DefaultInterval = 2 * time.Second
+
+	minBackoff = 1 * time.Second
+	maxBackoff = 30 * time.Second
+)
+
+// done is the terminal status GCE reports for a completed operation.
+const done = "DONE"
+
+// StateRefreshFunc mirrors the common Terraform-provider waiter shape: it
+// returns the latest view of the resource being polled, its status string,
+// and any error encountered fetching it. ComputeOperationWaiter builds one of
+// these internally, but it's exported so other packages can drive the same
+// WaitForState loop against a differently-shaped refresh.
+type StateRefreshFunc func(ctx context.Context) (op *compute.Operation, status string, err error)
+
+// ComputeOperationWaiter polls a single Compute Engine operation until it
+// reaches DONE, surfacing any operation-level errors.
+type ComputeOperationWaiter struct {
+	Service   *compute.Service
+	Operation *compute.Operation
+	Project   string
+	Type      Type
+
+	// Region and Zone are required when Type is Region or Zone,
+	// respectively, and ignored otherwise.
+	Region string
+	Zone   string
+
+	// Interval overrides DefaultInterval between non-retried polls.
+	Interval time.Duration
+}
+
+// RefreshFunc returns a StateRefreshFunc that fetches the current state of
+// w.Operation from the correct Global/Region/Zone Operations.Get endpoint.
+func (w *ComputeOperationWaiter) RefreshFunc() (StateRefreshFunc, error) {
+	name := w.Operation.Name
+	if name == "" {
+		return nil, errors.New("gcpops: operation has no name")
+	}
+
+	switch w.Type {
+	case Global:
+		return func(ctx context.Context) (*compute.Operation, string, error) {
+			op, err := w.Service.GlobalOperations.Get(w.Project, name).Context(ctx).Do()
+			if err != nil {
+				return nil, "", err
+			}
+			return op, op.Status, nil
+		}, nil
+	case Region:
+		if w.Region == "" {
+			return nil, errors.New("gcpops: Region is required for a Region-type waiter")
+		}
+		return func(ctx context.Context) (*compute.Operation, string, error) {
+			op, err := w.Service.RegionOperations.Get(w.Project, w.Region, name).Context(ctx).Do()
+			if err != nil {
+				return nil, "", err
+			}
+			return op, op.Status, nil
+		}, nil
+	case Zone:
+		if w.Zone == "" {
+			return nil, errors.New("gcpops: Zone is required for a Zone-type waiter")
+		}
+		return func(ctx context.Context) (*compute.Operation, string, error) {
+			op, err := w.Service.ZoneOperations.Get(w.Project, w.Zone, name).Context(ctx).Do()
+			if err != nil {
+				return nil, "", err
+			}
+			return op, op.Status, nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("gcpops: unknown operation type %v", w.Type)
+	}
+}
+
+// WaitForState polls until the operation reaches DONE, timeout elapses, or
+// the operation completes with one or more errors attached. Transient
+// request errors are retried with exponential backoff capped at maxBackoff;
+// any other error aborts immediately.
+func (w *ComputeOperationWaiter) WaitForState(ctx context.Context, timeout time.Duration) (*compute.Operation, error) {
+	refresh, err := w.RefreshFunc()
+	if err != nil {
+		return nil, err
+	}
+
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	deadline := time.Now().Add(timeout)
+	backoff := minBackoff
+
+	for {
+		op, status, err := refresh(ctx)
+		if err != nil {
+			if !isTransientError(err) {
+				return nil, fmt.Errorf("gcpops: polling %s operation %s: %w", w.Type, w.Operation.Name, err)
+			}
+			if time.Now().Add(backoff).After(deadline) {
+				return nil, fmt.Errorf("gcpops: timed out waiting for %s operation %s after transient errors: %w", w.Type, w.Operation.Name, err)
+			}
+			sleep(ctx, backoff)
+			backoff = nextBackoff(backoff)
+			continue
+		}
+		backoff = minBackoff
+
+		if status == done {
+			if op.Error != nil && len(op.Error.Errors) > 0 {
+				return op, joinOperationErrors(op.Error.Errors)
+			}
+			return op, nil
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return op, fmt.Errorf("gcpops: timed out waiting for %s operation %s to reach DONE (last status %q)", w.Type, w.Operation.Name, status)
+		}
+		sleep(ctx, interval)
+	}
+}
+
+// joinOperationErrors flattens a completed operation's per-error details
+// into a single joined error so callers can use errors.Is/As normally.
+func joinOperationErrors(opErrors []*compute.OperationErrorErrors) error {
+	errs := make([]error, 0, len(opErrors))
+	for _, e := range opErrors {
+		errs = append(errs, fmt.Errorf("%s: %s", e.Code, e.Message))
+	}
+	return errors.Join(errs...)
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+func nextBackoff(current time.Duration) time.Duration {
+	next := time.Duration(float64(current) * 2)
+	if next > maxBackoff {
+		return maxBackoff
+	}
+	return next
+}
+
+// isTransientError reports whether err looks like a retryable rate-limit or
+// server-side failure from the Compute Engine API rather than a permanent
+// request error.
+func isTransientError(err error) bool {
+	var gErr *googleapi.Error
+	if errors.As(err, &gErr) {
+		return gErr.Code == 429 || gErr.Code >= 500
+	}
+	return false
+}
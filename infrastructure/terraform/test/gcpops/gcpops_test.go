@@ -0,0 +1,163 @@
+package gcpops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+)
+
+// newFakeComputeService points a *compute.Service at a test server that
+// responds to Global/Region/Zone Operations.Get requests with whatever
+// handler the test registers, so WaitForState can be exercised without real
+// GCE credentials or network access.
+func newFakeComputeService(t *testing.T, handler http.HandlerFunc) *compute.Service {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	svc, err := compute.NewService(context.Background(),
+		option.WithEndpoint(server.URL),
+		option.WithHTTPClient(server.Client()),
+		option.WithoutAuthentication(),
+	)
+	if err != nil {
+		t.Fatalf("creating fake compute service: %v", err)
+	}
+	return svc
+}
+
+func writeOperation(t *testing.T, w http.ResponseWriter, op *compute.Operation) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(op); err != nil {
+		t.Fatalf("encoding fake operation response: %v", err)
+	}
+}
+
+func TestWaitForStateGlobalOperationReachesDone(t *testing.T) {
+	calls := 0
+	svc := newFakeComputeService(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		status := "RUNNING"
+		if calls >= 2 {
+			status = "DONE"
+		}
+		writeOperation(t, w, &compute.Operation{Name: "op-1", Status: status})
+	})
+
+	waiter := &ComputeOperationWaiter{
+		Service:   svc,
+		Operation: &compute.Operation{Name: "op-1"},
+		Project:   "test-project",
+		Type:      Global,
+		Interval:  10 * time.Millisecond,
+	}
+
+	op, err := waiter.WaitForState(context.Background(), time.Second)
+	if err != nil {
+		t.Fatalf("WaitForState returned an error: %v", err)
+	}
+	if op.Status != "DONE" {
+		t.Fatalf("expected final status DONE, got %q", op.Status)
+	}
+	if calls < 2 {
+		t.Fatalf("expected WaitForState to poll at least twice, polled %d times", calls)
+	}
+}
+
+func TestWaitForStateRegionOperationSurfacesOperationErrors(t *testing.T) {
+	svc := newFakeComputeService(t, func(w http.ResponseWriter, r *http.Request) {
+		writeOperation(t, w, &compute.Operation{
+			Name:   "op-2",
+			Status: "DONE",
+			Error: &compute.OperationError{
+				Errors: []*compute.OperationErrorErrors{
+					{Code: "RESOURCE_IN_USE", Message: "subnetwork is still in use"},
+				},
+			},
+		})
+	})
+
+	waiter := &ComputeOperationWaiter{
+		Service:   svc,
+		Operation: &compute.Operation{Name: "op-2"},
+		Project:   "test-project",
+		Type:      Region,
+		Region:    "us-central1",
+	}
+
+	_, err := waiter.WaitForState(context.Background(), time.Second)
+	if err == nil {
+		t.Fatal("expected WaitForState to return the operation's error, got nil")
+	}
+	if got := err.Error(); got == "" {
+		t.Fatal("expected a non-empty joined error message")
+	}
+}
+
+func TestWaitForStateZoneOperationTimesOut(t *testing.T) {
+	svc := newFakeComputeService(t, func(w http.ResponseWriter, r *http.Request) {
+		writeOperation(t, w, &compute.Operation{Name: "op-3", Status: "RUNNING"})
+	})
+
+	waiter := &ComputeOperationWaiter{
+		Service:   svc,
+		Operation: &compute.Operation{Name: "op-3"},
+		Project:   "test-project",
+		Type:      Zone,
+		Zone:      "us-central1-a",
+		Interval:  10 * time.Millisecond,
+	}
+
+	_, err := waiter.WaitForState(context.Background(), 50*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected WaitForState to time out, got nil error")
+	}
+}
+
+func TestRefreshFuncRequiresRegionAndZone(t *testing.T) {
+	svc := newFakeComputeService(t, func(w http.ResponseWriter, r *http.Request) {
+		writeOperation(t, w, &compute.Operation{Name: "op-4", Status: "DONE"})
+	})
+
+	cases := []struct {
+		name   string
+		waiter *ComputeOperationWaiter
+	}{
+		{
+			name:   "region without Region set",
+			waiter: &ComputeOperationWaiter{Service: svc, Operation: &compute.Operation{Name: "op-4"}, Project: "p", Type: Region},
+		},
+		{
+			name:   "zone without Zone set",
+			waiter: &ComputeOperationWaiter{Service: svc, Operation: &compute.Operation{Name: "op-4"}, Project: "p", Type: Zone},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.waiter.RefreshFunc(); err == nil {
+				t.Fatal("expected RefreshFunc to reject a waiter missing its Region/Zone")
+			}
+		})
+	}
+}
+
+func TestTypeString(t *testing.T) {
+	cases := map[Type]string{Global: "global", Region: "region", Zone: "zone"}
+	for typ, want := range cases {
+		if got := typ.String(); got != want {
+			t.Fatalf("Type(%d).String() = %q, want %q", typ, got, want)
+		}
+	}
+	if got := Type(99).String(); got != "unknown" {
+		t.Fatalf("Type(99).String() = %q, want %q", got, "unknown")
+	}
+}
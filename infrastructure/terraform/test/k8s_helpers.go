@@ -0,0 +1,186 @@
+// =============================================================================
+// Kubernetes-Native Validation Helpers
+// =============================================================================
+// CreateComputeOptions("kubernetes", ...) stands up an EKS/GKE cluster and a
+// workload, but nothing in this package previously verified the workload
+// actually came up inside that cluster. These helpers fill that gap using
+// k8s.io/client-go directly against the kubeconfig the compute module's
+// "kubeconfig" output produces, rather than shelling out to kubectl.
+// =============================================================================
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// K8sOptions bundles the client-go clientset and namespace the validation
+// helpers below operate against.
+type K8sOptions struct {
+	Clientset *kubernetes.Clientset
+	Namespace string
+}
+
+// CreateK8sOptions builds a K8sOptions from the kubeconfig produced by the
+// compute module's "kubeconfig" output, falling back to the KUBECONFIG env
+// var for clusters not managed by this test run. It creates
+// config.K8sNamespace if it doesn't already exist and registers its deletion
+// via t.Cleanup, so callers get the same "clean up after yourself" behavior
+// RunTerraformTest gives Terraform-managed resources.
+func CreateK8sOptions(t *testing.T, config *TestConfig, options *terraform.Options) *K8sOptions {
+	t.Helper()
+
+	kubeconfigPath := resolveKubeconfigPath(t, options)
+
+	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	require.NoError(t, err, "building Kubernetes client config from %s", kubeconfigPath)
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	require.NoError(t, err, "creating Kubernetes clientset")
+
+	k8sOptions := &K8sOptions{Clientset: clientset, Namespace: config.K8sNamespace}
+	ensureNamespace(t, config, k8sOptions)
+
+	return k8sOptions
+}
+
+// resolveKubeconfigPath writes the module's "kubeconfig" output to a temp
+// file if present, otherwise falls back to KUBECONFIG.
+func resolveKubeconfigPath(t *testing.T, options *terraform.Options) string {
+	t.Helper()
+
+	kubeconfig, err := terraform.OutputE(t, options, "kubeconfig")
+	if err != nil || kubeconfig == "" {
+		path := getEnvOrDefault("KUBECONFIG", "")
+		require.NotEmpty(t, path, "module produced no \"kubeconfig\" output and KUBECONFIG is unset")
+		return path
+	}
+
+	file, err := os.CreateTemp("", "terratest-kubeconfig-")
+	require.NoError(t, err, "creating temp file for kubeconfig output")
+	t.Cleanup(func() {
+		if err := os.Remove(file.Name()); err != nil {
+			t.Logf("Error removing temp kubeconfig %s: %v", file.Name(), err)
+		}
+	})
+
+	_, err = file.WriteString(kubeconfig)
+	require.NoError(t, file.Close())
+	require.NoError(t, err, "writing kubeconfig output to temp file")
+
+	return file.Name()
+}
+
+// ensureNamespace creates k8sOptions.Namespace if it doesn't already exist
+// and registers its deletion via t.Cleanup, honoring SkipCleanup the same
+// way RunTerraformTest's destroy stage does.
+func ensureNamespace(t *testing.T, config *TestConfig, k8sOptions *K8sOptions) {
+	t.Helper()
+	ctx := context.Background()
+
+	_, err := k8sOptions.Clientset.CoreV1().Namespaces().Get(ctx, k8sOptions.Namespace, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: k8sOptions.Namespace}}
+		_, err = k8sOptions.Clientset.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{})
+		require.NoError(t, err, "creating namespace %s", k8sOptions.Namespace)
+	} else {
+		require.NoError(t, err, "looking up namespace %s", k8sOptions.Namespace)
+	}
+
+	t.Cleanup(func() {
+		if config.SkipCleanup {
+			t.Logf("SkipCleanup=true, namespace %s left in place", k8sOptions.Namespace)
+			return
+		}
+		deleteCtx := context.Background()
+		if err := k8sOptions.Clientset.CoreV1().Namespaces().Delete(deleteCtx, k8sOptions.Namespace, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			t.Logf("Error deleting namespace %s: %v", k8sOptions.Namespace, err)
+		}
+	})
+}
+
+// ValidateNamespaceExists asserts that k8sOptions.Namespace exists in the
+// cluster.
+func ValidateNamespaceExists(t *testing.T, k8sOptions *K8sOptions) {
+	t.Helper()
+	_, err := k8sOptions.Clientset.CoreV1().Namespaces().Get(context.Background(), k8sOptions.Namespace, metav1.GetOptions{})
+	require.NoError(t, err, "namespace %s should exist", k8sOptions.Namespace)
+}
+
+// ValidateDeploymentReady asserts that deploymentName's ready replica count
+// matches its desired replica count, waiting up to timeout for the rollout
+// to finish.
+func ValidateDeploymentReady(t *testing.T, k8sOptions *K8sOptions, deploymentName string, timeout time.Duration) {
+	t.Helper()
+
+	err := WaitForResource(t, timeout, func() bool {
+		deployment, err := k8sOptions.Clientset.AppsV1().Deployments(k8sOptions.Namespace).Get(context.Background(), deploymentName, metav1.GetOptions{})
+		if err != nil {
+			return false
+		}
+		return deployment.Status.ReadyReplicas == *deployment.Spec.Replicas
+	}, fmt.Sprintf("deployment %s/%s to become ready", k8sOptions.Namespace, deploymentName))
+	require.NoError(t, err)
+}
+
+// ValidateServiceEndpoint asserts that serviceName has at least one ready
+// endpoint address, i.e. it's actually routing to a running pod rather than
+// just existing as an object.
+func ValidateServiceEndpoint(t *testing.T, k8sOptions *K8sOptions, serviceName string, timeout time.Duration) {
+	t.Helper()
+
+	err := WaitForResource(t, timeout, func() bool {
+		endpoints, err := k8sOptions.Clientset.CoreV1().Endpoints(k8sOptions.Namespace).Get(context.Background(), serviceName, metav1.GetOptions{})
+		if err != nil {
+			return false
+		}
+		for _, subset := range endpoints.Subsets {
+			if len(subset.Addresses) > 0 {
+				return true
+			}
+		}
+		return false
+	}, fmt.Sprintf("service %s/%s to have a ready endpoint", k8sOptions.Namespace, serviceName))
+	require.NoError(t, err)
+}
+
+// WaitForPodReady waits up to timeout for the pod matching labelSelector
+// (e.g. "app=tenant-management-service") to report Ready.
+func WaitForPodReady(t *testing.T, k8sOptions *K8sOptions, labelSelector string, timeout time.Duration) {
+	t.Helper()
+
+	err := WaitForResource(t, timeout, func() bool {
+		pods, err := k8sOptions.Clientset.CoreV1().Pods(k8sOptions.Namespace).List(context.Background(), metav1.ListOptions{LabelSelector: labelSelector})
+		if err != nil || len(pods.Items) == 0 {
+			return false
+		}
+		for _, pod := range pods.Items {
+			if !isPodReady(pod) {
+				return false
+			}
+		}
+		return true
+	}, fmt.Sprintf("pods matching %q in %s to become ready", labelSelector, k8sOptions.Namespace))
+	require.NoError(t, err)
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
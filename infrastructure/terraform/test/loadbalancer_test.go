@@ -0,0 +1,213 @@
+// =============================================================================
+// Load Balancer Module Tests
+// =============================================================================
+// Tests for the AWS ALB and GCP forwarding-rule/target-pool load balancer
+// modules, plus the cloud-agnostic loadbalancer wrapper module
+// =============================================================================
+
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// =============================================================================
+// AWS Load Balancer Module Tests
+// =============================================================================
+
+func TestAWSLoadBalancerModuleBasic(t *testing.T) {
+	t.Parallel()
+	SkipIfMissingAWSCredentials(t)
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "loadbalancer/aws")
+
+	vars := map[string]interface{}{
+		"vpc_id":           "vpc-12345678",
+		"subnet_ids":       []string{"subnet-1", "subnet-2"},
+		"ecs_service_name": fmt.Sprintf("%s-%s-service", TestPrefix, config.UniqueID),
+		"ecs_cluster_arn":  "arn:aws:ecs:us-east-1:123456789012:cluster/test-cluster",
+		"container_port":   8080,
+		"tags":             config.Tags,
+	}
+
+	options := CreateLoadBalancerOptions(t, config, "aws", modulePath, vars)
+	defer terraform.Destroy(t, options)
+
+	terraform.InitAndApply(t, options)
+
+	lbAddress := ValidateOutputNotEmpty(t, options, "lb_address")
+	assert.NotEmpty(t, lbAddress, "lb_address output should be populated")
+	ValidateOutputNotEmpty(t, options, "lb_id")
+	ValidateOutputMapHasKey(t, options, "loadbalancer_config", "provider")
+}
+
+func TestAWSLoadBalancerPlanOnly(t *testing.T) {
+	t.Parallel()
+	SkipIfMissingAWSCredentials(t)
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "loadbalancer/aws")
+
+	vars := map[string]interface{}{
+		"vpc_id":           "vpc-12345678",
+		"subnet_ids":       []string{"subnet-1", "subnet-2"},
+		"ecs_service_name": fmt.Sprintf("%s-%s-service", TestPrefix, config.UniqueID),
+		"ecs_cluster_arn":  "arn:aws:ecs:us-east-1:123456789012:cluster/test-cluster",
+		"container_port":   8080,
+		"tags":             config.Tags,
+	}
+
+	options := CreateLoadBalancerOptions(t, config, "aws", modulePath, vars)
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "aws_lb", "Plan should create an Application Load Balancer")
+	assert.Contains(t, planOutput, "aws_lb_target_group", "Plan should create a target group")
+	assert.Contains(t, planOutput, "aws_lb_listener", "Plan should create a listener")
+}
+
+// =============================================================================
+// GCP Load Balancer Module Tests
+// =============================================================================
+
+func TestGCPLoadBalancerModuleBasic(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	if config.GCPProjectID == "" {
+		t.Skip("GCP_PROJECT_ID not set")
+	}
+
+	modulePath := GetModulePath(config.ModulesRootDir, "loadbalancer/gcp")
+
+	vars := map[string]interface{}{
+		"project_id":     config.GCPProjectID,
+		"region":         config.GCPRegion,
+		"instance_group": fmt.Sprintf("projects/%s/zones/%s-a/instanceGroups/gke-node-pool", config.GCPProjectID, config.GCPRegion),
+		"target_port":    8080,
+		"labels":         config.Tags,
+	}
+
+	options := CreateLoadBalancerOptions(t, config, "gcp", modulePath, vars)
+	defer terraform.Destroy(t, options)
+
+	terraform.InitAndApply(t, options)
+
+	lbAddress := ValidateOutputNotEmpty(t, options, "lb_address")
+	assert.NotEmpty(t, lbAddress, "lb_address output should be populated")
+	ValidateOutputNotEmpty(t, options, "lb_id")
+	ValidateOutputMapHasKey(t, options, "loadbalancer_config", "provider")
+}
+
+func TestGCPLoadBalancerPlanOnly(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	if config.GCPProjectID == "" {
+		t.Skip("GCP_PROJECT_ID not set")
+	}
+
+	modulePath := GetModulePath(config.ModulesRootDir, "loadbalancer/gcp")
+
+	vars := map[string]interface{}{
+		"project_id":     config.GCPProjectID,
+		"region":         config.GCPRegion,
+		"instance_group": fmt.Sprintf("projects/%s/zones/%s-a/instanceGroups/gke-node-pool", config.GCPProjectID, config.GCPRegion),
+		"target_port":    8080,
+		"labels":         config.Tags,
+	}
+
+	options := CreateLoadBalancerOptions(t, config, "gcp", modulePath, vars)
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "google_compute_forwarding_rule", "Plan should create a forwarding rule")
+	assert.Contains(t, planOutput, "google_compute_target_pool", "Plan should create a target pool")
+	assert.Contains(t, planOutput, "google_compute_http_health_check", "Plan should create an HTTP health check")
+}
+
+// =============================================================================
+// Cloud-Agnostic Load Balancer Tests
+// =============================================================================
+
+func TestCloudAgnosticLoadBalancerAWS(t *testing.T) {
+	t.Parallel()
+	SkipIfMissingAWSCredentials(t)
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "loadbalancer")
+
+	vars := map[string]interface{}{
+		"cloud_provider": "aws",
+		"name_prefix":    fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":    "test",
+		"tags":           config.Tags,
+		"aws_config": map[string]interface{}{
+			"vpc_id":           "vpc-12345678",
+			"subnet_ids":       []string{"subnet-1", "subnet-2"},
+			"ecs_service_name": fmt.Sprintf("%s-%s-service", TestPrefix, config.UniqueID),
+			"ecs_cluster_arn":  "arn:aws:ecs:us-east-1:123456789012:cluster/test-cluster",
+			"container_port":   8080,
+		},
+	}
+
+	options := &terraform.Options{
+		TerraformDir: modulePath,
+		Vars:         vars,
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": config.AWSRegion,
+		},
+		NoColor: true,
+	}
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "module.aws", "Plan should use AWS module")
+}
+
+func TestCloudAgnosticLoadBalancerGCP(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	if config.GCPProjectID == "" {
+		t.Skip("GCP_PROJECT_ID not set")
+	}
+
+	modulePath := GetModulePath(config.ModulesRootDir, "loadbalancer")
+
+	vars := map[string]interface{}{
+		"cloud_provider": "gcp",
+		"name_prefix":    fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":    "test",
+		"tags":           config.Tags,
+		"gcp_config": map[string]interface{}{
+			"project_id":     config.GCPProjectID,
+			"region":         config.GCPRegion,
+			"instance_group": fmt.Sprintf("projects/%s/zones/%s-a/instanceGroups/gke-node-pool", config.GCPProjectID, config.GCPRegion),
+			"target_port":    8080,
+		},
+	}
+
+	options := &terraform.Options{
+		TerraformDir: modulePath,
+		Vars:         vars,
+		EnvVars: map[string]string{
+			"GOOGLE_PROJECT": config.GCPProjectID,
+			"GOOGLE_REGION":  config.GCPRegion,
+		},
+		NoColor: true,
+	}
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "module.gcp", "Plan should use GCP module")
+}
@@ -0,0 +1,110 @@
+// =============================================================================
+// Inline Terraform Module Sources
+// =============================================================================
+// ModuleSource lets a test point a terraform.Options at either a real module
+// directory under ModulesRootDir (Remote) or a handful of HCL files supplied
+// inline as strings (Inline). Inline sources are materialized into a temp
+// directory on Resolve, cleaned up via t.Cleanup, so contributors can write
+// self-contained unit-style tests for schema/plan behavior without carving
+// out a real module directory first.
+// =============================================================================
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+// ModuleSourceKind identifies whether a ModuleSource resolves to an
+// existing directory on disk or a set of inline HCL files.
+type ModuleSourceKind int
+
+const (
+	RemoteModuleSource ModuleSourceKind = iota
+	InlineModuleSource
+)
+
+// ModuleSource describes where a Terraform module's configuration comes
+// from. Build one with RemoteModule, InlineModule, or InlineModuleHCL.
+type ModuleSource struct {
+	Kind ModuleSourceKind
+
+	// Path is the module directory to use when Kind is RemoteModuleSource.
+	Path string
+
+	// Files maps filename to contents for Kind == InlineModuleSource, e.g.
+	// {"main.tf": "resource \"null_resource\" \"x\" {}"}.
+	Files map[string]string
+}
+
+// RemoteModule builds a ModuleSource pointing at an existing module
+// directory, equivalent to passing modulePath directly to a CreateXxxOptions
+// builder.
+func RemoteModule(path string) ModuleSource {
+	return ModuleSource{Kind: RemoteModuleSource, Path: path}
+}
+
+// InlineModule builds a ModuleSource from raw HCL files keyed by filename.
+func InlineModule(files map[string]string) ModuleSource {
+	return ModuleSource{Kind: InlineModuleSource, Files: files}
+}
+
+// InlineModuleHCL is a convenience wrapper for the common case of a single
+// main.tf file.
+func InlineModuleHCL(hcl string) ModuleSource {
+	return InlineModule(map[string]string{"main.tf": hcl})
+}
+
+// Resolve returns the on-disk directory terraform.Options.TerraformDir
+// should point at. Remote sources resolve to Path unchanged; inline sources
+// are materialized into a fresh temp directory, removed automatically via
+// t.Cleanup.
+func (m ModuleSource) Resolve(t *testing.T) string {
+	t.Helper()
+
+	switch m.Kind {
+	case RemoteModuleSource:
+		return m.Path
+	case InlineModuleSource:
+		dir, err := os.MkdirTemp("", "terratest-inline-module-")
+		require.NoError(t, err, "creating temp dir for inline module source")
+		t.Cleanup(func() {
+			if err := os.RemoveAll(dir); err != nil {
+				t.Logf("Error cleaning up inline module dir %s: %v", dir, err)
+			}
+		})
+
+		for name, contents := range m.Files {
+			path := filepath.Join(dir, name)
+			require.NoError(t, os.WriteFile(path, []byte(contents), 0o644), "writing inline module file %s", name)
+		}
+		return dir
+	default:
+		t.Fatalf("module_source: unknown ModuleSource kind %v", m.Kind)
+		return ""
+	}
+}
+
+// CreateAWSNetworkOptionsFromSource is CreateAWSNetworkOptions for a
+// ModuleSource instead of a bare modulePath, so tests can exercise inline HCL
+// without a real network/aws module directory.
+func CreateAWSNetworkOptionsFromSource(t *testing.T, config *TestConfig, source ModuleSource, vars map[string]interface{}) *terraform.Options {
+	return CreateAWSNetworkOptions(t, config, source.Resolve(t), vars)
+}
+
+// CreateGCPNetworkOptionsFromSource is CreateGCPNetworkOptions for a
+// ModuleSource instead of a bare modulePath.
+func CreateGCPNetworkOptionsFromSource(t *testing.T, config *TestConfig, source ModuleSource, vars map[string]interface{}) *terraform.Options {
+	return CreateGCPNetworkOptions(t, config, source.Resolve(t), vars)
+}
+
+// CreateDatabaseOptionsFromSource is CreateDatabaseOptions for a
+// ModuleSource instead of a bare modulePath.
+func CreateDatabaseOptionsFromSource(t *testing.T, config *TestConfig, provider string, source ModuleSource, vars map[string]interface{}) *terraform.Options {
+	return CreateDatabaseOptions(t, config, provider, source.Resolve(t), vars)
+}
@@ -0,0 +1,54 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestInlineModuleSourcePlansWithoutARealModuleDirectory demonstrates the
+// self-contained use case the Inline ModuleSource is meant for: plan an ad
+// hoc snippet of HCL without carving out a module directory under
+// ModulesRootDir first.
+func TestInlineModuleSourcePlansWithoutARealModuleDirectory(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+
+	source := InlineModuleHCL(`
+variable "name" {
+  type = string
+}
+
+resource "null_resource" "this" {
+  triggers = {
+    name = var.name
+  }
+}
+
+output "name" {
+  value = var.name
+}
+`)
+
+	options := &terraform.Options{
+		TerraformDir: source.Resolve(t),
+		Vars: map[string]interface{}{
+			"name": config.UniqueID,
+		},
+		NoColor: true,
+	}
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "null_resource.this", "Plan should create the inline module's resource")
+}
+
+func TestModuleSourceResolveRemoteReturnsPathUnchanged(t *testing.T) {
+	t.Parallel()
+
+	source := RemoteModule("/some/module/path")
+	assert.Equal(t, "/some/module/path", source.Resolve(t), "Remote sources should resolve to their Path unchanged")
+}
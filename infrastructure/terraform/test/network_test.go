@@ -12,7 +12,8 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
+
+	"github.com/ananta-platform/infrastructure-tests/gcpops"
 )
 
 // =============================================================================
@@ -108,6 +109,82 @@ func TestAWSNetworkModuleWithVPCEndpoints(t *testing.T) {
 	assert.Contains(t, dynamoEndpoint, "vpce-", "DynamoDB endpoint should have correct format")
 }
 
+// TestAWSNetworkModuleWithEdgeZones exercises a topology with one ordinary
+// AZ and one Wavelength zone, mirroring the CAPA network model where every
+// subnet carries a ZoneType and route tables are distinct per zone type.
+func TestAWSNetworkModuleWithEdgeZones(t *testing.T) {
+	t.Parallel()
+	SkipIfMissingAWSCredentials(t)
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "network/aws")
+
+	vars := map[string]interface{}{
+		"edge_zones": []string{"us-east-1-wl1-bos-wlz-1"},
+		"edge_zone_subnet_cidrs": map[string]string{
+			"us-east-1-wl1-bos-wlz-1": "10.99.100.0/24",
+		},
+	}
+
+	options := CreateAWSNetworkOptions(t, config, modulePath, vars)
+	defer terraform.Destroy(t, options)
+
+	terraform.InitAndApply(t, options)
+
+	carrierGatewayID := ValidateOutputNotEmpty(t, options, "carrier_gateway_id")
+	assert.Contains(t, carrierGatewayID, "cagw-", "Carrier gateway ID should have correct format")
+
+	networkConfig := terraform.OutputMap(t, options, "network_config")
+	assert.Equal(t, "wavelength-zone", networkConfig["us-east-1-wl1-bos-wlz-1"], "Wavelength subnet should report zone_type wavelength-zone")
+	assert.Equal(t, "availability-zone", networkConfig[config.AWSRegion+"a"], "Primary AZ subnet should report zone_type availability-zone")
+}
+
+func TestAWSNetworkModuleWithEdgeZonesPlanOnly(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "network/aws")
+
+	vars := map[string]interface{}{
+		"edge_zones": []string{"us-east-1-wl1-bos-wlz-1"},
+		"edge_zone_subnet_cidrs": map[string]string{
+			"us-east-1-wl1-bos-wlz-1": "10.99.100.0/24",
+		},
+		"local_zones": []string{"us-east-1-bos-1"},
+		"local_zone_subnet_cidrs": map[string]string{
+			"us-east-1-bos-1": "10.99.110.0/24",
+		},
+	}
+
+	options := CreateAWSNetworkOptions(t, config, modulePath, vars)
+
+	terraform.Init(t, options)
+	planOutput := terraform.Plan(t, options)
+
+	assert.Contains(t, planOutput, "aws_ec2_carrier_gateway", "Plan should create a carrier gateway for the Wavelength zone")
+	assert.Contains(t, planOutput, "aws_route_table.wavelength", "Plan should create a distinct route table for Wavelength subnets")
+	assert.Contains(t, planOutput, "aws_route_table.local_zone", "Plan should create a distinct route table for Local Zone subnets routed via the parent AZ's NAT")
+}
+
+func TestAWSNetworkModuleWithEdgeZonesRejectsInvalidZoneType(t *testing.T) {
+	t.Parallel()
+
+	config := NewTestConfig(t)
+	modulePath := GetModulePath(config.ModulesRootDir, "network/aws")
+
+	vars := map[string]interface{}{
+		"edge_zones": []string{"us-east-1-not-a-real-zone"},
+		"edge_zone_subnet_cidrs": map[string]string{
+			"us-east-1-not-a-real-zone": "10.99.120.0/24",
+		},
+	}
+
+	options := CreateAWSNetworkOptions(t, config, modulePath, vars)
+
+	_, err := terraform.InitAndPlanE(t, options)
+	assert.Error(t, err, "Plan should fail validation when an edge zone name doesn't match a known local-zone or wavelength-zone pattern")
+}
+
 // =============================================================================
 // GCP Network Module Tests
 // =============================================================================
@@ -194,7 +271,16 @@ func TestGCPNetworkModuleWithCloudNAT(t *testing.T) {
 	}
 
 	options := CreateGCPNetworkOptions(t, config, modulePath, vars)
-	defer terraform.Destroy(t, options)
+	defer func() {
+		terraform.Destroy(t, options)
+
+		// Confirm the router/NAT delete operation actually reached DONE
+		// before this test returns, so a parallel run reusing the same
+		// VPC doesn't race a half-deleted Cloud Router.
+		if opName, err := terraform.OutputE(t, options, "last_router_operation_name"); err == nil && opName != "" {
+			WaitForGCEOperationDone(t, config, gcpops.Region, config.GCPRegion, "", opName)
+		}
+	}()
 
 	terraform.InitAndApply(t, options)
 
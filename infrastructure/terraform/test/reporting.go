@@ -0,0 +1,281 @@
+// =============================================================================
+// Structured Test Reporting
+// =============================================================================
+// TestReporter records the outcome of each Terratest lifecycle stage (init,
+// apply, validate, destroy) so CI systems can ingest results the same way
+// they would `terraform test`'s experimental JUnit output: as JUnit XML,
+// JSON, or a plain-text summary. Output location is controlled by
+// TERRATEST_REPORT_DIR (directory) and TERRATEST_JUNIT_XML (filename within
+// that directory, default "junit.xml"). RunTerraformTest gives every test a
+// reporter of its own, so WriteReports namespaces its filenames by the
+// module/test names the reporter actually recorded - otherwise concurrent
+// t.Parallel() tests would all write the same fixed filenames and only the
+// last writer's results would survive.
+// =============================================================================
+
+package test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StageStatus is the outcome of a single lifecycle stage.
+type StageStatus string
+
+const (
+	StagePass    StageStatus = "pass"
+	StageFail    StageStatus = "fail"
+	StageSkipped StageStatus = "skipped"
+)
+
+const (
+	// ReportDirEnvVar names the directory reports are written to. If unset,
+	// WriteReports is a no-op.
+	ReportDirEnvVar = "TERRATEST_REPORT_DIR"
+
+	// JUnitXMLEnvVar names the JUnit XML file within ReportDirEnvVar.
+	JUnitXMLEnvVar = "TERRATEST_JUNIT_XML"
+
+	defaultJUnitXMLFile = "junit.xml"
+	jsonReportFile      = "terratest-report.json"
+	summaryReportFile   = "terratest-summary.txt"
+)
+
+// StageResult captures a single init/apply/validate/destroy stage for one
+// module under test.
+type StageResult struct {
+	Name     string        `json:"name"`
+	Status   StageStatus   `json:"status"`
+	Duration time.Duration `json:"duration"`
+	Output   string        `json:"output,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// ModuleReport aggregates every stage recorded for a single module/test.
+type ModuleReport struct {
+	Name   string        `json:"name"`
+	Stages []StageResult `json:"stages"`
+}
+
+// TestReporter aggregates stage results across modules for a single test
+// run, and serializes them on demand as JUnit XML, JSON, and plain text.
+type TestReporter struct {
+	mu      sync.Mutex
+	modules map[string]*ModuleReport
+	order   []string
+}
+
+// NewTestReporter creates an empty TestReporter.
+func NewTestReporter() *TestReporter {
+	return &TestReporter{modules: make(map[string]*ModuleReport)}
+}
+
+// RecordStage records the outcome of a single lifecycle stage for moduleName.
+func (r *TestReporter) RecordStage(moduleName, stageName string, status StageStatus, duration time.Duration, output string, stageErr error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	module, ok := r.modules[moduleName]
+	if !ok {
+		module = &ModuleReport{Name: moduleName}
+		r.modules[moduleName] = module
+		r.order = append(r.order, moduleName)
+	}
+
+	result := StageResult{
+		Name:     stageName,
+		Status:   status,
+		Duration: duration,
+		Output:   output,
+	}
+	if stageErr != nil {
+		result.Error = stageErr.Error()
+	}
+	module.Stages = append(module.Stages, result)
+}
+
+// RunStage runs fn, timing it and recording its outcome under moduleName. A
+// non-nil return from fn is recorded as StageFail and returned to the caller
+// unchanged.
+func (r *TestReporter) RunStage(moduleName, stageName string, fn func() (string, error)) error {
+	start := time.Now()
+	output, err := fn()
+	status := StagePass
+	if err != nil {
+		status = StageFail
+	}
+	r.RecordStage(moduleName, stageName, status, time.Since(start), output, err)
+	return err
+}
+
+// Modules returns the recorded module reports in the order they were first
+// touched.
+func (r *TestReporter) Modules() []*ModuleReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	modules := make([]*ModuleReport, 0, len(r.order))
+	for _, name := range r.order {
+		modules = append(modules, r.modules[name])
+	}
+	return modules
+}
+
+// WriteReports writes the JUnit XML, JSON, and plain-text summary reports to
+// the directory named by TERRATEST_REPORT_DIR. It is a no-op if that env var
+// is unset, since most local `go test` runs have nowhere to put them.
+func (r *TestReporter) WriteReports() error {
+	dir := os.Getenv(ReportDirEnvVar)
+	if dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("reporting: creating report dir %s: %w", dir, err)
+	}
+
+	modules := r.Modules()
+	suffix := reportSuffix(modules)
+
+	junitFile := os.Getenv(JUnitXMLEnvVar)
+	if junitFile == "" {
+		junitFile = defaultJUnitXMLFile
+	}
+	if err := writeJUnitXML(filepath.Join(dir, withSuffix(junitFile, suffix)), modules); err != nil {
+		return err
+	}
+	if err := writeJSONReport(filepath.Join(dir, withSuffix(jsonReportFile, suffix)), modules); err != nil {
+		return err
+	}
+	return writeSummary(filepath.Join(dir, withSuffix(summaryReportFile, suffix)), modules)
+}
+
+// reportSuffix builds a filename suffix from the module/test names a
+// TestReporter recorded, so WriteReports doesn't clobber another
+// TestReporter's output in the same TERRATEST_REPORT_DIR - every
+// RunTerraformTest call constructs its own reporter, and with t.Parallel()
+// many can be writing to that directory at once.
+func reportSuffix(modules []*ModuleReport) string {
+	names := make([]string, 0, len(modules))
+	for _, module := range modules {
+		names = append(names, sanitizeForFilename(module.Name))
+	}
+	return strings.Join(names, "-")
+}
+
+// sanitizeForFilename replaces characters that are awkward or unsafe in a
+// filename (path separators, whitespace) with underscores.
+func sanitizeForFilename(name string) string {
+	return strings.NewReplacer("/", "_", " ", "_", ":", "_").Replace(name)
+}
+
+// withSuffix inserts suffix before filename's extension, e.g.
+// withSuffix("junit.xml", "MyModule") -> "junit.MyModule.xml". A blank
+// suffix returns filename unchanged.
+func withSuffix(filename, suffix string) string {
+	if suffix == "" {
+		return filename
+	}
+	ext := filepath.Ext(filename)
+	base := strings.TrimSuffix(filename, ext)
+	return base + "." + suffix + ext
+}
+
+// =============================================================================
+// JUnit XML
+// =============================================================================
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TimeSecs  float64         `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	TimeSecs  float64       `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+func writeJUnitXML(path string, modules []*ModuleReport) error {
+	suites := junitTestSuites{}
+
+	for _, module := range modules {
+		suite := junitTestSuite{Name: module.Name}
+		for _, stage := range module.Stages {
+			suite.Tests++
+			suite.TimeSecs += stage.Duration.Seconds()
+
+			tc := junitTestCase{
+				Name:      stage.Name,
+				ClassName: module.Name,
+				TimeSecs:  stage.Duration.Seconds(),
+				SystemOut: stage.Output,
+			}
+			switch stage.Status {
+			case StageFail:
+				suite.Failures++
+				tc.Failure = &junitMessage{Message: stage.Error, Content: stage.Error}
+			case StageSkipped:
+				suite.Skipped++
+				tc.Skipped = &junitMessage{Message: "skipped"}
+			}
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return fmt.Errorf("reporting: marshaling JUnit XML: %w", err)
+	}
+	return os.WriteFile(path, append([]byte(xml.Header), data...), 0o644)
+}
+
+// =============================================================================
+// JSON and plain-text
+// =============================================================================
+
+func writeJSONReport(path string, modules []*ModuleReport) error {
+	data, err := json.MarshalIndent(modules, "", "  ")
+	if err != nil {
+		return fmt.Errorf("reporting: marshaling JSON report: %w", err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func writeSummary(path string, modules []*ModuleReport) error {
+	var b strings.Builder
+	for _, module := range modules {
+		fmt.Fprintf(&b, "%s\n", module.Name)
+		for _, stage := range module.Stages {
+			fmt.Fprintf(&b, "  [%s] %s (%s)\n", strings.ToUpper(string(stage.Status)), stage.Name, stage.Duration)
+			if stage.Error != "" {
+				fmt.Fprintf(&b, "    error: %s\n", stage.Error)
+			}
+		}
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
@@ -8,6 +8,7 @@ package test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -20,6 +21,9 @@ import (
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	test_structure "github.com/gruntwork-io/terratest/modules/test-structure"
 	"github.com/stretchr/testify/require"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/ananta-platform/infrastructure-tests/gcpops"
 )
 
 // =============================================================================
@@ -41,6 +45,9 @@ const (
 	DefaultGCPRegion  = "us-central1"
 	DefaultGCPProject = "" // Set via TF_VAR_project_id or GCP_PROJECT_ID env var
 
+	// Azure regions for testing
+	DefaultAzureRegion = "eastus"
+
 	// Kubernetes
 	DefaultK8sNamespace = "terratest"
 )
@@ -51,14 +58,52 @@ const (
 
 // TestConfig holds common test configuration
 type TestConfig struct {
-	UniqueID       string
-	AWSRegion      string
-	GCPRegion      string
-	GCPProjectID   string
-	K8sNamespace   string
-	Tags           map[string]string
-	SkipCleanup    bool
-	ModulesRootDir string
+	UniqueID            string
+	AWSRegion           string
+	GCPRegion           string
+	GCPProjectID        string
+	AzureRegion         string
+	AzureSubscriptionID string
+	K8sNamespace        string
+	Tags                map[string]string
+	SkipCleanup         bool
+	ModulesRootDir      string
+
+	// RetryableErrors adds provider-specific regex->reason entries (AWS
+	// throttling, GCP quota exhaustion, EKS IAM propagation delays, etc.) on
+	// top of terraform.DefaultRetryableTerraformErrors. See ApplyRetryConfig.
+	RetryableErrors map[string]string
+	MaxRetries      int
+	RetryInterval   time.Duration
+
+	// PostApplyValidators run between the "deploy" and "validate" stages of
+	// RunTerraformTest, giving a module test a security/compliance/cost gate
+	// (tfsec, checkov, infracost, ...) without duplicating that boilerplate
+	// per test. Findings are recorded through the TestReporter; nil (the
+	// default) skips the stage entirely.
+	PostApplyValidators []Validator
+}
+
+// DefaultMaxRetries and DefaultRetryInterval govern how many times, and how
+// far apart, RunTerraformTest retries an apply/destroy that fails with a
+// retryable error. See ApplyRetryConfig.
+const (
+	DefaultMaxRetries    = 3
+	DefaultRetryInterval = 5 * time.Second
+)
+
+// defaultProviderRetryableErrors adds the transient, provider-specific
+// failures seen in this repo's CI runs on top of
+// terraform.DefaultRetryableTerraformErrors: AWS API throttling, GCP quota
+// exhaustion, and EKS IAM/auth propagation delays right after a role or
+// policy is created.
+var defaultProviderRetryableErrors = map[string]string{
+	`(?s).*ThrottlingException.*`:                   "AWS API throttling",
+	`(?s).*RequestLimitExceeded.*`:                  "AWS API rate limit exceeded",
+	`(?s).*rateLimitExceeded.*`:                     "GCP quota exhaustion",
+	`(?s).*quotaExceeded.*`:                         "GCP quota exhaustion",
+	`(?s).*error validating provider credentials.*`: "EKS IAM role propagation delay",
+	`(?s).*AccessDenied.*assumed-role.*`:            "IAM role/policy propagation delay",
 }
 
 // NewTestConfig creates a new test configuration with defaults
@@ -66,19 +111,34 @@ func NewTestConfig(t *testing.T) *TestConfig {
 	uniqueID := strings.ToLower(random.UniqueId())
 
 	return &TestConfig{
-		UniqueID:       uniqueID,
-		AWSRegion:      getEnvOrDefault("AWS_DEFAULT_REGION", DefaultAWSRegion),
-		GCPRegion:      getEnvOrDefault("GCP_REGION", DefaultGCPRegion),
-		GCPProjectID:   getEnvOrDefault("GCP_PROJECT_ID", getEnvOrDefault("TF_VAR_project_id", DefaultGCPProject)),
-		K8sNamespace:   fmt.Sprintf("%s-%s", DefaultK8sNamespace, uniqueID),
-		SkipCleanup:    os.Getenv("SKIP_CLEANUP") == "true",
-		ModulesRootDir: getModulesRootDir(),
+		UniqueID:            uniqueID,
+		AWSRegion:           getEnvOrDefault("AWS_DEFAULT_REGION", DefaultAWSRegion),
+		GCPRegion:           getEnvOrDefault("GCP_REGION", DefaultGCPRegion),
+		GCPProjectID:        getEnvOrDefault("GCP_PROJECT_ID", getEnvOrDefault("TF_VAR_project_id", DefaultGCPProject)),
+		AzureRegion:         getEnvOrDefault("AZURE_REGION", DefaultAzureRegion),
+		AzureSubscriptionID: getEnvOrDefault("AZURE_SUBSCRIPTION_ID", ""),
+		K8sNamespace:        fmt.Sprintf("%s-%s", DefaultK8sNamespace, uniqueID),
+		SkipCleanup:         os.Getenv("SKIP_CLEANUP") == "true",
+		ModulesRootDir:      getModulesRootDir(),
 		Tags: map[string]string{
 			"Environment": "test",
 			"ManagedBy":   "terratest",
 			"TestID":      uniqueID,
 		},
+		RetryableErrors: cloneStringMap(defaultProviderRetryableErrors),
+		MaxRetries:      DefaultMaxRetries,
+		RetryInterval:   DefaultRetryInterval,
+	}
+}
+
+// cloneStringMap returns a shallow copy of m so callers can mutate their own
+// copy of a shared default without racing other parallel tests.
+func cloneStringMap(m map[string]string) map[string]string {
+	clone := make(map[string]string, len(m))
+	for k, v := range m {
+		clone[k] = v
 	}
+	return clone
 }
 
 // =============================================================================
@@ -155,6 +215,7 @@ func CreateDatabaseOptions(t *testing.T, config *TestConfig, provider string, mo
 		"name_prefix":    fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
 		"environment":    "test",
 		"instance_size":  "micro",
+		"engine":         "postgres",
 		"engine_version": "15",
 		"database_name":  "testdb",
 		"master_username": "testuser",
@@ -169,6 +230,8 @@ func CreateDatabaseOptions(t *testing.T, config *TestConfig, provider string, mo
 	} else if provider == "gcp" {
 		envVars["GOOGLE_PROJECT"] = config.GCPProjectID
 		envVars["GOOGLE_REGION"] = config.GCPRegion
+	} else if provider == "azure" {
+		envVars["ARM_SUBSCRIPTION_ID"] = config.AzureSubscriptionID
 	}
 
 	return &terraform.Options{
@@ -199,6 +262,8 @@ func CreateCacheOptions(t *testing.T, config *TestConfig, provider string, modul
 	} else if provider == "gcp" {
 		envVars["GOOGLE_PROJECT"] = config.GCPProjectID
 		envVars["GOOGLE_REGION"] = config.GCPRegion
+	} else if provider == "azure" {
+		envVars["ARM_SUBSCRIPTION_ID"] = config.AzureSubscriptionID
 	}
 
 	return &terraform.Options{
@@ -218,6 +283,13 @@ func CreateComputeOptions(t *testing.T, config *TestConfig, provider string, mod
 		"cluster_size":  "small",
 	}
 
+	// subnet_region defaults to the control-plane region, but callers can
+	// override it to point at a pre-existing subnet that lives in a
+	// different region than the cluster.
+	if provider == "gcp" {
+		defaultVars["subnet_region"] = config.GCPRegion
+	}
+
 	mergedVars := mergeVars(defaultVars, vars)
 
 	envVars := map[string]string{}
@@ -252,6 +324,35 @@ func CreateSecretsOptions(t *testing.T, config *TestConfig, provider string, mod
 
 	mergedVars := mergeVars(defaultVars, vars)
 
+	envVars := map[string]string{}
+	if provider == "aws" {
+		envVars["AWS_DEFAULT_REGION"] = config.AWSRegion
+	} else if provider == "gcp" {
+		envVars["GOOGLE_PROJECT"] = config.GCPProjectID
+		envVars["GOOGLE_REGION"] = config.GCPRegion
+	} else if provider == "azure" {
+		envVars["ARM_SUBSCRIPTION_ID"] = config.AzureSubscriptionID
+	}
+
+	return &terraform.Options{
+		TerraformDir: modulePath,
+		Vars:         mergedVars,
+		EnvVars:      envVars,
+		NoColor:      true,
+		Logger:       getLogger(t),
+	}
+}
+
+// CreateLoadBalancerOptions creates Terraform options for loadbalancer module testing
+func CreateLoadBalancerOptions(t *testing.T, config *TestConfig, provider string, modulePath string, vars map[string]interface{}) *terraform.Options {
+	defaultVars := map[string]interface{}{
+		"name_prefix":       fmt.Sprintf("%s-%s", TestPrefix, config.UniqueID),
+		"environment":       "test",
+		"health_check_path": "/healthz",
+	}
+
+	mergedVars := mergeVars(defaultVars, vars)
+
 	envVars := map[string]string{}
 	if provider == "aws" {
 		envVars["AWS_DEFAULT_REGION"] = config.AWSRegion
@@ -273,31 +374,161 @@ func CreateSecretsOptions(t *testing.T, config *TestConfig, provider string, mod
 // Test Lifecycle Helpers
 // =============================================================================
 
-// RunTerraformTest runs a complete Terraform test lifecycle with stage support
-func RunTerraformTest(t *testing.T, options *terraform.Options, testName string, validateFunc func(*testing.T, *terraform.Options)) {
+// ApplyRetryConfig layers config.RetryableErrors on top of
+// terraform.DefaultRetryableTerraformErrors and sets MaxRetries/
+// TimeBetweenRetries from config, returning a copy of options that
+// terraform.ApplyE/DestroyE will retry automatically on a matching error.
+func ApplyRetryConfig(t *testing.T, config *TestConfig, options *terraform.Options) *terraform.Options {
+	retryOptions := terraform.WithDefaultRetryableErrors(t, options)
+	for pattern, reason := range config.RetryableErrors {
+		retryOptions.RetryableTerraformErrors[pattern] = reason
+	}
+	retryOptions.MaxRetries = config.MaxRetries
+	retryOptions.TimeBetweenRetries = config.RetryInterval
+	return retryOptions
+}
+
+// workingDirDataFile prefixes the name of the stable pointer RunTerraformTest
+// leaves next to a module's original directory, one per test name, recording
+// which isolated temp copy an earlier process invocation ran the deploy stage
+// against. See resolveWorkingDir.
+const workingDirDataFile = "working_dir"
+
+// resolveWorkingDir returns the working directory Terraform commands should
+// run against for moduleDir. The first call copies moduleDir into an
+// isolated temp directory via test_structure.CopyTerraformFolderToTemp (so
+// t.Parallel() tests never collide on the same .terraform/terraform.tfstate
+// files) and remembers that temp path next to moduleDir itself, under a name
+// keyed by t.Name() so parallel tests of the same module each keep their own
+// copy. Later process invocations of the same test - e.g. a local debugging
+// loop doing SKIP_DEPLOY=true go test -run ... to resume only the validate
+// stage - load that remembered path instead of asking
+// CopyTerraformFolderToTemp for a brand-new, empty one.
+func resolveWorkingDir(t *testing.T, moduleDir string) string {
+	t.Helper()
+
+	pointerName := workingDirDataFile + "_" + sanitizeForFilename(t.Name())
+	pointerPath := test_structure.FormatTestDataPath(moduleDir, pointerName+".json")
+	if test_structure.IsTestDataPresent(t, pointerPath) {
+		return test_structure.LoadString(t, moduleDir, pointerName)
+	}
+
+	workingDir := test_structure.CopyTerraformFolderToTemp(t, filepath.Dir(moduleDir), filepath.Base(moduleDir))
+	test_structure.SaveString(t, moduleDir, pointerName, workingDir)
+	return workingDir
+}
+
+// RunTerraformTest runs a complete Terraform test lifecycle with stage
+// support. The module is copied into an isolated per-test working directory
+// (see resolveWorkingDir) so that t.Parallel() tests never collide on the
+// same .terraform/terraform.tfstate files; a small checkpoint (module path,
+// vars, outputs) is persisted into that directory between stages so that a
+// local debugging loop can re-run only the validate stage with
+// SKIP_DEPLOY=true go test -run ... against infrastructure a previous
+// process invocation already applied. SKIP_INIT, SKIP_DEPLOY, and
+// SKIP_VALIDATE toggle their respective stages the same way; SKIP_CLEANUP
+// keeps its existing meaning of leaving applied infrastructure in place.
+func RunTerraformTest(t *testing.T, config *TestConfig, options *terraform.Options, testName string, validateFunc func(*testing.T, *terraform.Options)) {
+	moduleDir := options.TerraformDir
+	workingDir := resolveWorkingDir(t, moduleDir)
+	options.TerraformDir = workingDir
+	options = ApplyRetryConfig(t, config, options)
+
+	// Keep any outputs a previous invocation's deploy stage saved, so a
+	// resumed SKIP_DEPLOY=true run doesn't wipe them before validating.
+	checkpoint := loadCheckpoint(t, workingDir)
+	checkpoint.ModulePath = workingDir
+	checkpoint.Vars = options.Vars
+	saveCheckpoint(t, workingDir, checkpoint)
+
+	reporter := NewTestReporter()
+	defer func() {
+		if err := reporter.WriteReports(); err != nil {
+			t.Logf("Error writing test reports for %s: %v", testName, err)
+		}
+	}()
+
 	// CRITICAL FIX: Setup cleanup FIRST - BEFORE any stages run
 	// This ensures cleanup runs even if validation or other stages fail
-	defer test_structure.RunTestStage(t, "cleanup", func() {
+	defer test_structure.RunTestStage(t, "DESTROY", func() {
 		if os.Getenv("SKIP_CLEANUP") != "true" {
-			// Use DestroyE to capture errors instead of panicking
-			if err := terraform.DestroyE(t, options); err != nil {
-				t.Logf("Error during cleanup in %s: %v", options.TerraformDir, err)
+			err := reporter.RunStage(testName, "destroy", func() (string, error) {
+				// Use DestroyE to capture errors instead of panicking
+				return terraform.DestroyE(t, options)
+			})
+			if err == nil {
+				// The working dir pointer only makes sense while there's
+				// applied infra behind it; drop it so the next run starts
+				// a fresh isolated copy instead of reusing a torn-down one.
+				test_structure.CleanupTestDataFolder(t, moduleDir)
 			}
 		} else {
+			reporter.RecordStage(testName, "destroy", StageSkipped, 0, "", nil)
 			t.Logf("SKIP_CLEANUP=true, resources in %s not destroyed", options.TerraformDir)
 		}
 	})
 
-	// Stage: Deploy
-	test_structure.RunTestStage(t, "deploy", func() {
-		terraform.InitAndApply(t, options)
+	// Stage: Init
+	test_structure.RunTestStage(t, "INIT", func() {
+		reporter.RunStage(testName, "init", func() (string, error) {
+			return terraform.InitE(t, options)
+		})
+	})
+
+	// Stage: Apply
+	test_structure.RunTestStage(t, "DEPLOY", func() {
+		reporter.RunStage(testName, "apply", func() (string, error) {
+			output, err := terraform.ApplyE(t, options)
+			if err == nil {
+				checkpoint := loadCheckpoint(t, workingDir)
+				checkpoint.Outputs = terraform.OutputAll(t, options)
+				saveCheckpoint(t, workingDir, checkpoint)
+			}
+			return output, err
+		})
+	})
+
+	// Stage: Post-apply validation (tfsec/checkov/infracost, ...). Runs after
+	// DEPLOY and before VALIDATE so security/compliance/cost findings gate
+	// the test before any module-specific output assertions run.
+	test_structure.RunTestStage(t, "POLICY", func() {
+		for _, validator := range config.PostApplyValidators {
+			start := time.Now()
+			result, err := validator.Validate(t, options.TerraformDir)
+			output, marshalErr := json.Marshal(result)
+			if marshalErr != nil {
+				output = []byte(marshalErr.Error())
+			}
+
+			stageName := fmt.Sprintf("policy:%s", validator.Name())
+			if err != nil {
+				reporter.RecordStage(testName, stageName, StageFail, time.Since(start), string(output), err)
+				t.Errorf("%s: %v", validator.Name(), err)
+				continue
+			}
+
+			status := StagePass
+			if exceeding := result.Exceeds(validator.FailureThreshold()); len(exceeding) > 0 {
+				status = StageFail
+				for _, finding := range exceeding {
+					t.Errorf("%s: [%s] %s: %s", validator.Name(), finding.Severity, finding.RuleID, finding.Message)
+				}
+			}
+			reporter.RecordStage(testName, stageName, status, time.Since(start), string(output), nil)
+		}
 	})
 
 	// Stage: Validate
-	test_structure.RunTestStage(t, "validate", func() {
+	test_structure.RunTestStage(t, "VALIDATE", func() {
+		start := time.Now()
 		if validateFunc != nil {
 			validateFunc(t, options)
 		}
+		status := StagePass
+		if t.Failed() {
+			status = StageFail
+		}
+		reporter.RecordStage(testName, "validate", status, time.Since(start), "", nil)
 	})
 }
 
@@ -393,6 +624,20 @@ func SkipIfMissingGCPCredentials(t *testing.T) {
 	}
 }
 
+// SkipIfMissingAzureCredentials skips test if Azure credentials are not configured
+func SkipIfMissingAzureCredentials(t *testing.T) {
+	if os.Getenv("ARM_CLIENT_ID") == "" && os.Getenv("AZURE_CLIENT_ID") == "" {
+		t.Skip("Azure credentials not configured, skipping Azure tests")
+	}
+}
+
+// SkipIfMissingKubernetesCredentials skips test if no kubeconfig is available
+func SkipIfMissingKubernetesCredentials(t *testing.T) {
+	if os.Getenv("KUBECONFIG") == "" {
+		t.Skip("KUBECONFIG not set, skipping Kubernetes tests")
+	}
+}
+
 // getLogger returns conditional logger based on environment variables
 func getLogger(t *testing.T) *logger.Logger {
 	if os.Getenv("TF_LOG") == "1" || os.Getenv("TERRATEST_LOG") == "1" {
@@ -442,3 +687,35 @@ func WaitForResource(t *testing.T, timeout time.Duration, checkFunc func() bool,
 		}
 	}
 }
+
+// WaitForGCEOperationDone blocks until the named Compute Engine operation
+// reaches DONE, using gcpops.ComputeOperationWaiter, so a GKE/network test's
+// DestroyE doesn't return before the underlying subnets, routers, NAT
+// gateways, or node pools have actually finished deleting. It is a no-op
+// when operationName is empty, which is expected for plan-only tests or
+// modules that don't yet surface an operation-name output.
+func WaitForGCEOperationDone(t *testing.T, config *TestConfig, opType gcpops.Type, region, zone, operationName string) {
+	t.Helper()
+	if operationName == "" {
+		return
+	}
+
+	ctx := context.Background()
+	svc, err := compute.NewService(ctx)
+	if err != nil {
+		t.Logf("gcpops: could not create Compute Engine client to confirm operation %s reached DONE: %v", operationName, err)
+		return
+	}
+
+	waiter := &gcpops.ComputeOperationWaiter{
+		Service:   svc,
+		Operation: &compute.Operation{Name: operationName},
+		Project:   config.GCPProjectID,
+		Type:      opType,
+		Region:    region,
+		Zone:      zone,
+	}
+	if _, err := waiter.WaitForState(ctx, gcpops.DefaultTimeout); err != nil {
+		t.Logf("gcpops: operation %s did not reach DONE cleanly: %v", operationName, err)
+	}
+}
@@ -0,0 +1,273 @@
+// =============================================================================
+// Post-Apply Security and Cost Validation
+// =============================================================================
+// Validator is a pluggable hook RunTerraformTest runs against the applied
+// working directory between the "deploy" and "validate" stages, giving every
+// AWS/GCP module test a cross-cutting security/compliance/cost gate without
+// duplicating tfsec/checkov/infracost boilerplate per test. Built-in
+// adapters shell out to the real CLIs and are a no-op (logged, not failed)
+// when the binary isn't on PATH, since these tools aren't assumed to be
+// installed in every environment this suite runs in.
+// =============================================================================
+
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"testing"
+)
+
+// ValidationSeverity orders findings so callers can compare against a
+// threshold regardless of which tool reported them.
+type ValidationSeverity int
+
+const (
+	SeverityInfo ValidationSeverity = iota
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+func (s ValidationSeverity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "INFO"
+	case SeverityLow:
+		return "LOW"
+	case SeverityMedium:
+		return "MEDIUM"
+	case SeverityHigh:
+		return "HIGH"
+	case SeverityCritical:
+		return "CRITICAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func parseSeverity(s string) ValidationSeverity {
+	switch s {
+	case "LOW":
+		return SeverityLow
+	case "MEDIUM":
+		return SeverityMedium
+	case "HIGH":
+		return SeverityHigh
+	case "CRITICAL":
+		return SeverityCritical
+	default:
+		return SeverityInfo
+	}
+}
+
+// ValidationFinding is a single issue reported by a Validator.
+type ValidationFinding struct {
+	RuleID   string             `json:"rule_id"`
+	Message  string             `json:"message"`
+	Severity ValidationSeverity `json:"severity"`
+}
+
+// ValidationResult is everything a single Validator run produced.
+type ValidationResult struct {
+	Validator string              `json:"validator"`
+	Findings  []ValidationFinding `json:"findings"`
+	RawOutput string              `json:"raw_output,omitempty"`
+}
+
+// Validator is implemented by every post-apply security/cost gate.
+type Validator interface {
+	// Name identifies the validator in reports and log output.
+	Name() string
+	// Validate runs against workingDir (the applied module's directory) and
+	// returns its findings. An error here means the tool itself failed to
+	// run, not that it found issues - issues are reported as Findings.
+	Validate(t *testing.T, workingDir string) (ValidationResult, error)
+	// FailureThreshold is the minimum severity at which a Finding fails the
+	// test.
+	FailureThreshold() ValidationSeverity
+}
+
+// defaultSeverityThreshold is used by a built-in Validator whose Threshold
+// field was left at its zero value (SeverityInfo), i.e. not explicitly
+// configured by the caller.
+const defaultSeverityThreshold = SeverityHigh
+
+// effectiveThreshold substitutes defaultSeverityThreshold for an unconfigured
+// (zero-value) threshold so a Validator built as e.g. TfsecValidator{} still
+// has a sane fail gate.
+func effectiveThreshold(threshold ValidationSeverity) ValidationSeverity {
+	if threshold == SeverityInfo {
+		return defaultSeverityThreshold
+	}
+	return threshold
+}
+
+// Exceeds reports the findings in r at or above threshold.
+func (r ValidationResult) Exceeds(threshold ValidationSeverity) []ValidationFinding {
+	var exceeding []ValidationFinding
+	for _, finding := range r.Findings {
+		if finding.Severity >= threshold {
+			exceeding = append(exceeding, finding)
+		}
+	}
+	return exceeding
+}
+
+// =============================================================================
+// tfsec
+// =============================================================================
+
+// TfsecValidator runs `tfsec <dir> --format json` and fails findings at or
+// above Threshold.
+type TfsecValidator struct {
+	Threshold ValidationSeverity
+}
+
+func (v TfsecValidator) Name() string { return "tfsec" }
+
+func (v TfsecValidator) FailureThreshold() ValidationSeverity { return effectiveThreshold(v.Threshold) }
+
+func (v TfsecValidator) Validate(t *testing.T, workingDir string) (ValidationResult, error) {
+	result := ValidationResult{Validator: v.Name()}
+
+	if _, err := exec.LookPath("tfsec"); err != nil {
+		t.Logf("tfsec not found on PATH, skipping security scan for %s", workingDir)
+		return result, nil
+	}
+
+	// tfsec exits non-zero when it finds issues, so ignore the exit code and
+	// parse whatever JSON it produced.
+	output, _ := exec.Command("tfsec", workingDir, "--format", "json", "--no-color").Output()
+	result.RawOutput = string(output)
+
+	var parsed struct {
+		Results []struct {
+			RuleID      string `json:"rule_id"`
+			Description string `json:"description"`
+			Severity    string `json:"severity"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(output, &parsed); err != nil {
+		return result, fmt.Errorf("tfsec: parsing JSON output: %w", err)
+	}
+
+	for _, r := range parsed.Results {
+		result.Findings = append(result.Findings, ValidationFinding{
+			RuleID:   r.RuleID,
+			Message:  r.Description,
+			Severity: parseSeverity(r.Severity),
+		})
+	}
+	return result, nil
+}
+
+// =============================================================================
+// checkov
+// =============================================================================
+
+// CheckovValidator runs `checkov -d <dir> --output json` and fails findings
+// at or above Threshold.
+type CheckovValidator struct {
+	Threshold ValidationSeverity
+}
+
+func (v CheckovValidator) Name() string { return "checkov" }
+
+func (v CheckovValidator) FailureThreshold() ValidationSeverity {
+	return effectiveThreshold(v.Threshold)
+}
+
+func (v CheckovValidator) Validate(t *testing.T, workingDir string) (ValidationResult, error) {
+	result := ValidationResult{Validator: v.Name()}
+
+	if _, err := exec.LookPath("checkov"); err != nil {
+		t.Logf("checkov not found on PATH, skipping compliance scan for %s", workingDir)
+		return result, nil
+	}
+
+	output, _ := exec.Command("checkov", "-d", workingDir, "--output", "json", "--compact").Output()
+	result.RawOutput = string(output)
+
+	var parsed struct {
+		Results struct {
+			FailedChecks []struct {
+				CheckID   string `json:"check_id"`
+				CheckName string `json:"check_name"`
+				Severity  string `json:"severity"`
+			} `json:"failed_checks"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(output, &parsed); err != nil {
+		return result, fmt.Errorf("checkov: parsing JSON output: %w", err)
+	}
+
+	for _, c := range parsed.Results.FailedChecks {
+		severity := parseSeverity(c.Severity)
+		if c.Severity == "" {
+			// checkov doesn't assign a severity to most built-in checks; a
+			// failed check is still a compliance gap worth surfacing.
+			severity = SeverityMedium
+		}
+		result.Findings = append(result.Findings, ValidationFinding{
+			RuleID:   c.CheckID,
+			Message:  c.CheckName,
+			Severity: severity,
+		})
+	}
+	return result, nil
+}
+
+// =============================================================================
+// infracost
+// =============================================================================
+
+// InfracostValidator runs `infracost breakdown --path <dir> --format json`
+// and fails if the estimated monthly cost exceeds MaxMonthlyCostUSD.
+type InfracostValidator struct {
+	MaxMonthlyCostUSD float64
+}
+
+func (v InfracostValidator) Name() string { return "infracost" }
+
+// FailureThreshold is SeverityCritical: the only finding this validator ever
+// produces is a budget overage, which should always fail the test.
+func (v InfracostValidator) FailureThreshold() ValidationSeverity { return SeverityCritical }
+
+func (v InfracostValidator) Validate(t *testing.T, workingDir string) (ValidationResult, error) {
+	result := ValidationResult{Validator: v.Name()}
+
+	if _, err := exec.LookPath("infracost"); err != nil {
+		t.Logf("infracost not found on PATH, skipping cost estimate for %s", workingDir)
+		return result, nil
+	}
+
+	output, err := exec.Command("infracost", "breakdown", "--path", workingDir, "--format", "json").Output()
+	result.RawOutput = string(output)
+	if err != nil {
+		return result, fmt.Errorf("infracost: running breakdown: %w", err)
+	}
+
+	var parsed struct {
+		TotalMonthlyCost string `json:"totalMonthlyCost"`
+	}
+	if err := json.Unmarshal(output, &parsed); err != nil {
+		return result, fmt.Errorf("infracost: parsing JSON output: %w", err)
+	}
+
+	var totalMonthlyCost float64
+	if _, err := fmt.Sscanf(parsed.TotalMonthlyCost, "%f", &totalMonthlyCost); err != nil {
+		return result, fmt.Errorf("infracost: parsing totalMonthlyCost %q: %w", parsed.TotalMonthlyCost, err)
+	}
+
+	if v.MaxMonthlyCostUSD > 0 && totalMonthlyCost > v.MaxMonthlyCostUSD {
+		result.Findings = append(result.Findings, ValidationFinding{
+			RuleID:   "estimated-monthly-cost",
+			Message:  fmt.Sprintf("estimated monthly cost $%.2f exceeds budget $%.2f", totalMonthlyCost, v.MaxMonthlyCostUSD),
+			Severity: SeverityCritical,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,105 @@
+package waiters
+
+import (
+	"context"
+	"fmt"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+// AWSRDSRefreshFunc returns a RefreshFunc that reports the DBInstanceStatus
+// of the given RDS instance identifier.
+func AWSRDSRefreshFunc(region, dbInstanceID string) (RefreshFunc, error) {
+	client, err := newRDSClient(region)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) (string, error) {
+		out, err := client.DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{
+			DBInstanceIdentifier: &dbInstanceID,
+		})
+		if err != nil {
+			return "", err
+		}
+		if len(out.DBInstances) == 0 {
+			return "", fmt.Errorf("rds instance %s not found", dbInstanceID)
+		}
+		return *out.DBInstances[0].DBInstanceStatus, nil
+	}, nil
+}
+
+// AWSElastiCacheRefreshFunc returns a RefreshFunc that reports the status of
+// the given ElastiCache replication group.
+func AWSElastiCacheRefreshFunc(region, replicationGroupID string) (RefreshFunc, error) {
+	client, err := newElastiCacheClient(region)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) (string, error) {
+		out, err := client.DescribeReplicationGroups(ctx, &elasticache.DescribeReplicationGroupsInput{
+			ReplicationGroupId: &replicationGroupID,
+		})
+		if err != nil {
+			return "", err
+		}
+		if len(out.ReplicationGroups) == 0 {
+			return "", fmt.Errorf("elasticache replication group %s not found", replicationGroupID)
+		}
+		return *out.ReplicationGroups[0].Status, nil
+	}, nil
+}
+
+// AWSSecretsReplicationRefreshFunc returns a RefreshFunc that reports the
+// aggregate replication status of a Secrets Manager secret: "InSync" once
+// every configured replica region reports InSync, the first non-InSync
+// status otherwise.
+func AWSSecretsReplicationRefreshFunc(region, secretID string) (RefreshFunc, error) {
+	client, err := newSecretsManagerClient(region)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) (string, error) {
+		out, err := client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+			SecretId: &secretID,
+		})
+		if err != nil {
+			return "", err
+		}
+		for _, replica := range out.ReplicationStatus {
+			if string(replica.Status) != "InSync" {
+				return string(replica.Status), nil
+			}
+		}
+		return "InSync", nil
+	}, nil
+}
+
+func newRDSClient(region string) (*rds.Client, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(context.Background(), awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return rds.NewFromConfig(cfg), nil
+}
+
+func newElastiCacheClient(region string) (*elasticache.Client, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(context.Background(), awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return elasticache.NewFromConfig(cfg), nil
+}
+
+func newSecretsManagerClient(region string) (*secretsmanager.Client, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(context.Background(), awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return secretsmanager.NewFromConfig(cfg), nil
+}
@@ -0,0 +1,44 @@
+package waiters
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/redis/v1"
+	"google.golang.org/api/sqladmin/v1beta4"
+)
+
+// GCPCloudSQLRefreshFunc returns a RefreshFunc that reports the State of the
+// given Cloud SQL instance.
+func GCPCloudSQLRefreshFunc(projectID, instanceName string) (RefreshFunc, error) {
+	svc, err := sqladmin.NewService(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("creating Cloud SQL admin client: %w", err)
+	}
+
+	return func(ctx context.Context) (string, error) {
+		instance, err := svc.Instances.Get(projectID, instanceName).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+		return instance.State, nil
+	}, nil
+}
+
+// GCPMemorystoreRefreshFunc returns a RefreshFunc that reports the State of
+// the given Memorystore (Redis) instance. name must be the fully-qualified
+// instance name: projects/{project}/locations/{location}/instances/{id}.
+func GCPMemorystoreRefreshFunc(name string) (RefreshFunc, error) {
+	svc, err := redis.NewService(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("creating Memorystore client: %w", err)
+	}
+
+	return func(ctx context.Context) (string, error) {
+		instance, err := svc.Projects.Locations.Instances.Get(name).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+		return instance.State, nil
+	}, nil
+}
@@ -0,0 +1,99 @@
+package waiters
+
+import (
+	"context"
+	"fmt"
+)
+
+// Default pending/target states per resource type, used when an Options
+// value leaves Pending/Target empty.
+var (
+	dbPendingStates = []string{"creating", "backing-up", "modifying", "PENDING_CREATE", "MAINTENANCE"}
+	dbTargetStates  = []string{"available", "RUNNABLE"}
+
+	cachePendingStates = []string{"creating", "modifying", "CREATING", "UPDATING"}
+	cacheTargetStates  = []string{"available", "READY"}
+
+	secretPendingStates = []string{"InProgress"}
+	secretTargetStates  = []string{"InSync"}
+)
+
+// WaitForDBReady waits for an RDS instance (AWS) or Cloud SQL instance (GCP)
+// to report its "available"/"RUNNABLE" status. resourceID is the RDS
+// DBInstanceIdentifier for AWS, or "<project_id>/<instance_name>" for GCP.
+func WaitForDBReady(ctx context.Context, t TestingT, provider, region, resourceID string, opts Options) (string, error) {
+	refresh, err := dbRefreshFunc(provider, region, resourceID)
+	if err != nil {
+		return "", err
+	}
+	return Wait(ctx, t, provider, resourceID, refresh, withDefaults(opts, dbPendingStates, dbTargetStates))
+}
+
+// WaitForCacheReady waits for an ElastiCache replication group (AWS) or
+// Memorystore instance (GCP) to report its "available"/"READY" status.
+// resourceID is the ReplicationGroupId for AWS, or the fully-qualified
+// instance name for GCP.
+func WaitForCacheReady(ctx context.Context, t TestingT, provider, region, resourceID string, opts Options) (string, error) {
+	refresh, err := cacheRefreshFunc(provider, region, resourceID)
+	if err != nil {
+		return "", err
+	}
+	return Wait(ctx, t, provider, resourceID, refresh, withDefaults(opts, cachePendingStates, cacheTargetStates))
+}
+
+// WaitForSecretReplicated waits until every configured replica region of a
+// Secrets Manager secret (AWS) reports "InSync". There is no GCP analog
+// since Secret Manager replication is synchronous, so it takes no provider
+// argument and always polls AWS Secrets Manager.
+func WaitForSecretReplicated(ctx context.Context, t TestingT, region, secretID string, opts Options) (string, error) {
+	refresh, err := AWSSecretsReplicationRefreshFunc(region, secretID)
+	if err != nil {
+		return "", err
+	}
+	return Wait(ctx, t, "aws", secretID, refresh, withDefaults(opts, secretPendingStates, secretTargetStates))
+}
+
+func dbRefreshFunc(provider, region, resourceID string) (RefreshFunc, error) {
+	switch provider {
+	case "aws":
+		return AWSRDSRefreshFunc(region, resourceID)
+	case "gcp":
+		projectID, instanceName, err := splitGCPResourceID(resourceID)
+		if err != nil {
+			return nil, err
+		}
+		return GCPCloudSQLRefreshFunc(projectID, instanceName)
+	default:
+		return nil, fmt.Errorf("waiters: unsupported provider %q for database readiness", provider)
+	}
+}
+
+func cacheRefreshFunc(provider, region, resourceID string) (RefreshFunc, error) {
+	switch provider {
+	case "aws":
+		return AWSElastiCacheRefreshFunc(region, resourceID)
+	case "gcp":
+		return GCPMemorystoreRefreshFunc(resourceID)
+	default:
+		return nil, fmt.Errorf("waiters: unsupported provider %q for cache readiness", provider)
+	}
+}
+
+func withDefaults(opts Options, pending, target []string) Options {
+	if len(opts.Pending) == 0 {
+		opts.Pending = pending
+	}
+	if len(opts.Target) == 0 {
+		opts.Target = target
+	}
+	return opts
+}
+
+func splitGCPResourceID(resourceID string) (projectID, instanceName string, err error) {
+	for i := len(resourceID) - 1; i >= 0; i-- {
+		if resourceID[i] == '/' {
+			return resourceID[:i], resourceID[i+1:], nil
+		}
+	}
+	return "", "", fmt.Errorf("waiters: GCP resource ID %q must be of the form <project_id>/<instance_name>", resourceID)
+}
@@ -0,0 +1,185 @@
+// =============================================================================
+// Cloud Resource Readiness Waiter
+// =============================================================================
+// A generic long-running operation waiter for apply-mode Terratest tests,
+// modeled on the ComputeOperationWaiter pattern: a RefreshFunc drives a
+// state-change loop with configurable timeout, polling interval, and
+// pending/target state classification. Resource-specific Get calls for AWS
+// and GCP live in aws.go and gcp.go; this file implements the shared loop.
+// =============================================================================
+
+package waiters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"google.golang.org/api/googleapi"
+)
+
+// DefaultTimeout and DefaultInterval are used when an Options value leaves
+// the corresponding field at its zero value.
+const (
+	DefaultTimeout  = 15 * time.Minute
+	DefaultInterval = 10 * time.Second
+
+	minBackoff = 1 * time.Second
+	maxBackoff = 30 * time.Second
+)
+
+// TestingT is the subset of *testing.T that this package depends on, so
+// callers can pass a real *testing.T without this package importing
+// "testing" directly into its public API in more places than necessary.
+type TestingT interface {
+	Logf(format string, args ...interface{})
+	Helper()
+}
+
+// RefreshFunc returns the current status of a resource. Transient errors
+// (throttling, 5xx) should be returned as-is so Wait can classify and retry
+// them; any other error makes Wait fail fast, returning it wrapped with the
+// provider and resource ID.
+type RefreshFunc func(ctx context.Context) (status string, err error)
+
+// Options configures a single Wait call.
+type Options struct {
+	// Timeout is the total time to wait before giving up. Defaults to
+	// DefaultTimeout.
+	Timeout time.Duration
+	// Interval is the polling interval between non-retried calls to the
+	// RefreshFunc. Defaults to DefaultInterval.
+	Interval time.Duration
+	// Pending lists the statuses that mean "still in progress".
+	Pending []string
+	// Target lists the statuses that mean "done, return success".
+	Target []string
+}
+
+// TerminalStateError is returned by Wait when the RefreshFunc reports a
+// status that is neither pending nor a target state.
+type TerminalStateError struct {
+	ResourceID string
+	Status     string
+}
+
+func (e *TerminalStateError) Error() string {
+	return fmt.Sprintf("resource %s reached terminal state %q", e.ResourceID, e.Status)
+}
+
+// Wait polls refresh until it reports one of opts.Target, opts.Timeout
+// elapses, or refresh reports a status outside both opts.Pending and
+// opts.Target (a terminal failure state). Transient errors (throttling,
+// 5xx) are retried with exponential backoff capped at maxBackoff; any
+// other error is returned immediately.
+func Wait(ctx context.Context, t TestingT, provider, resourceID string, refresh RefreshFunc, opts Options) (string, error) {
+	t.Helper()
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	deadline := time.Now().Add(timeout)
+	backoff := minBackoff
+
+	for attempt := 0; ; attempt++ {
+		status, err := refresh(ctx)
+		if err != nil {
+			if !isTransientError(err) {
+				return "", fmt.Errorf("waiting for %s resource %s: %w", provider, resourceID, err)
+			}
+
+			t.Logf("transient error waiting for %s resource %s (attempt %d): %v", provider, resourceID, attempt, err)
+			if time.Now().Add(backoff).After(deadline) {
+				return "", fmt.Errorf("timed out waiting for %s resource %s after transient errors: %w", provider, resourceID, err)
+			}
+			sleep(ctx, backoff)
+			backoff = nextBackoff(backoff)
+			continue
+		}
+		backoff = minBackoff
+
+		if contains(opts.Target, status) {
+			return status, nil
+		}
+		if !contains(opts.Pending, status) {
+			return status, &TerminalStateError{ResourceID: resourceID, Status: status}
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return status, fmt.Errorf("timed out waiting for %s resource %s to reach %v (last status %q)", provider, resourceID, opts.Target, status)
+		}
+
+		t.Logf("waiting for %s resource %s to reach %v (current status %q)", provider, resourceID, opts.Target, status)
+		sleep(ctx, interval)
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+func nextBackoff(current time.Duration) time.Duration {
+	next := time.Duration(float64(current) * 2)
+	if next > maxBackoff {
+		return maxBackoff
+	}
+	return next
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if strings.EqualFold(s, needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTransientError reports whether err looks like a retryable rate-limit or
+// server-side failure rather than a permanent request error.
+func isTransientError(err error) bool {
+	var respErr *smithyhttp.ResponseError
+	if errors.As(err, &respErr) {
+		if respErr.HTTPStatusCode() >= 500 || respErr.HTTPStatusCode() == 429 {
+			return true
+		}
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "Throttling", "ThrottlingException", "RequestLimitExceeded", "TooManyRequestsException":
+			return true
+		}
+	}
+
+	var gErr *googleapi.Error
+	if errors.As(err, &gErr) {
+		if gErr.Code == 429 || gErr.Code >= 500 {
+			return true
+		}
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, marker := range []string{"rate limit", "quota exceeded", "throttl", "connection reset", "timeout"} {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}